@@ -4,4 +4,4 @@ go 1.22.4
 
 require github.com/sirupsen/logrus v1.9.3
 
-require golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+require golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8
@@ -0,0 +1,44 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NormalizeTime converts a legacy src/v1 Head.Time, which holds unix
+// seconds, to a UTC time.Time.
+func NormalizeTime(v int) time.Time {
+	return time.Unix(int64(v), 0).UTC()
+}
+
+// NormalizeTimeMillis is like NormalizeTime for values in unix milliseconds.
+func NormalizeTimeMillis(v int64) time.Time {
+	return time.UnixMilli(v).UTC()
+}
+
+// UnmarshalJSON accepts "time" either as an RFC 3339 string or, for heads
+// produced by src/v1, as an integer number of unix seconds.
+func (h *Head) UnmarshalJSON(data []byte) error {
+	type head Head
+	aux := struct {
+		*head
+		Time json.RawMessage `json:"time"`
+	}{head: (*head)(h)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.Time) == 0 || string(aux.Time) == "null" {
+		return nil
+	}
+	if aux.Time[0] == '"' {
+		return json.Unmarshal(aux.Time, &h.Time)
+	}
+	var secs int
+	if err := json.Unmarshal(aux.Time, &secs); err != nil {
+		return fmt.Errorf("head time must be an RFC 3339 string or unix seconds: %w", err)
+	}
+	h.Time = NormalizeTime(secs)
+	return nil
+}
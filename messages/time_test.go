@@ -0,0 +1,71 @@
+package messages
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNormalizeTime(t *testing.T) {
+	want := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	if got := NormalizeTime(1700000000); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("NormalizeTime = %v, want %v", got, want)
+	}
+	want = want.Add(250 * time.Millisecond)
+	if got := NormalizeTimeMillis(1700000000250); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("NormalizeTimeMillis = %v, want %v", got, want)
+	}
+}
+
+func TestHeadUnmarshalTime(t *testing.T) {
+	tests := []struct {
+		name string
+		time string
+		want time.Time
+	}{
+		{"unix seconds", `1700000000`, time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)},
+		{"rfc3339", `"2023-11-14T23:13:20.5+01:00"`, time.Date(2023, 11, 14, 22, 13, 20, 5e8, time.UTC)},
+		{"null", `null`, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h Head
+			data := `{"destination":"d","time":` + tt.time + `,"correlation_id":"c","event_type":"e","source":"s"}`
+			if err := json.Unmarshal([]byte(data), &h); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !h.Time.Equal(tt.want) {
+				t.Errorf("time = %v, want %v", h.Time, tt.want)
+			}
+			if h.Destination != "d" || h.Correlationid != "c" || h.Eventtype != "e" || h.Source != "s" {
+				t.Errorf("other fields lost: %+v", h)
+			}
+		})
+	}
+
+	// A missing time leaves the zero value.
+	var h Head
+	if err := json.Unmarshal([]byte(`{"event_type":"e"}`), &h); err != nil || !h.Time.IsZero() {
+		t.Errorf("head = %+v, %v", h, err)
+	}
+}
+
+func TestHeadUnmarshalTimeInvalid(t *testing.T) {
+	for _, v := range []string{`1.5`, `true`, `"yesterday"`, `{}`} {
+		var h Head
+		if err := json.Unmarshal([]byte(`{"time":`+v+`}`), &h); err == nil {
+			t.Errorf("time %s accepted as %v", v, h.Time)
+		}
+	}
+}
+
+func TestMessageDecodesLegacyHead(t *testing.T) {
+	var m Message[SendingMessageBody]
+	raw := `{"head":{"time":1700000000,"event_type":"e"},"body":{"client_id":1,"message":"hi"}}`
+	if err := Convert([]byte(raw), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Head.Time.Unix() != 1700000000 || m.Body.ClientID != 1 || m.Body.Message != "hi" {
+		t.Errorf("message = %+v", m)
+	}
+}
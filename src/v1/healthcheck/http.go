@@ -0,0 +1,33 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPCheck performs a GET against url using the check's context, reporting
+// healthy on a 2xx response. A nil client uses http.DefaultClient.
+func HTTPCheck(url string, client *http.Client) func(context.Context) (bool, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) (bool, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return false, fmt.Errorf("failed to build health request for %s: %w", url, err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return false, fmt.Errorf("health request to %s failed: %w", url, err)
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return false, fmt.Errorf("health endpoint %s returned %s", url, resp.Status)
+		}
+		return true, nil
+	}
+}
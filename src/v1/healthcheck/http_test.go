@@ -0,0 +1,75 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPCheckStatuses(t *testing.T) {
+	tests := []struct {
+		status  int
+		healthy bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusNoContent, true},
+		{http.StatusMovedPermanently, false},
+		{http.StatusNotFound, false},
+		{http.StatusServiceUnavailable, false},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("method = %s, want GET", r.Method)
+			}
+			// Redirects would be followed; answer 301 without a Location.
+			w.WriteHeader(tt.status)
+		}))
+
+		ok, err := HTTPCheck(srv.URL, srv.Client())(context.Background())
+		if ok != tt.healthy || (err == nil) != tt.healthy {
+			t.Errorf("status %d: %v, %v", tt.status, ok, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), srv.URL) {
+			t.Errorf("status %d: error %q doesn't name the endpoint", tt.status, err)
+		}
+		srv.Close()
+	}
+}
+
+func TestHTTPCheckTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	if ok, err := HTTPCheck(url, nil)(context.Background()); ok || err == nil {
+		t.Errorf("closed server: %v, %v", ok, err)
+	}
+}
+
+func TestHTTPCheckHonorsDeadline(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	ok, err := HTTPCheck(srv.URL, srv.Client())(ctx)
+	if ok || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("slow server: %v, %v, want context.DeadlineExceeded", ok, err)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("check took %v despite the deadline", d)
+	}
+}
@@ -0,0 +1,66 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// LogWritableCheck reports healthy when the logger's output w and its log
+// directory still accept writes, catching a closed or failing writer and a
+// full or read-only log volume that would otherwise make logging stop
+// silently.
+//
+// w is probed with Sync when it has one (as logger.FileWriter does) and
+// with an empty Write otherwise, so nothing is added to the log. logDir, if
+// not empty, is probed by writing and removing a small file. The check
+// gives up when ctx is done.
+func LogWritableCheck(w io.Writer, logDir string) func(context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		result := make(chan error, 1)
+		go func() { result <- probeLog(w, logDir) }()
+
+		select {
+		case err := <-result:
+			if err != nil {
+				return false, err
+			}
+			return true, nil
+		case <-ctx.Done():
+			return false, fmt.Errorf("log writability probe did not finish: %w", ctx.Err())
+		}
+	}
+}
+
+func probeLog(w io.Writer, logDir string) error {
+	if s, ok := w.(interface{ Sync() error }); ok {
+		if err := s.Sync(); err != nil {
+			return fmt.Errorf("failed to sync log writer: %w", err)
+		}
+	} else if _, err := w.Write(nil); err != nil {
+		return fmt.Errorf("failed to write to log writer: %w", err)
+	}
+
+	if logDir == "" {
+		return nil
+	}
+	f, err := os.CreateTemp(logDir, ".healthcheck-*")
+	if err != nil {
+		return fmt.Errorf("failed to create probe file in %s: %w", logDir, err)
+	}
+	name := f.Name()
+	defer os.Remove(name)
+
+	_, err = f.Write([]byte("ok\n"))
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write probe file %s: %w", name, err)
+	}
+	return nil
+}
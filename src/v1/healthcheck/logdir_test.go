@@ -0,0 +1,87 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("no space left on device") }
+
+type okWriter struct{}
+
+func (okWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+type blockingSyncer struct{ release chan struct{} }
+
+func (w blockingSyncer) Write(p []byte) (int, error) { return len(p), nil }
+func (w blockingSyncer) Sync() error {
+	<-w.release
+	return nil
+}
+
+func TestLogWritableCheck(t *testing.T) {
+	dir := t.TempDir()
+	f, err := os.Create(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	ok, err := LogWritableCheck(f, dir)(context.Background())
+	if !ok || err != nil {
+		t.Fatalf("healthy log reported ok=%v err=%v", ok, err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("probe file left behind: %v", entries)
+	}
+	if info, _ := f.Stat(); info.Size() != 0 {
+		t.Errorf("probe wrote %d bytes to the log", info.Size())
+	}
+}
+
+func TestLogWritableCheckFailingWriter(t *testing.T) {
+	ok, err := LogWritableCheck(failingWriter{}, "")(context.Background())
+	if ok || err == nil {
+		t.Errorf("failing writer reported ok=%v err=%v", ok, err)
+	}
+}
+
+func TestLogWritableCheckClosedFile(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if ok, err := LogWritableCheck(f, "")(context.Background()); ok || err == nil {
+		t.Errorf("closed log file reported ok=%v err=%v", ok, err)
+	}
+}
+
+func TestLogWritableCheckMissingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "gone")
+	if ok, err := LogWritableCheck(okWriter{}, dir)(context.Background()); ok || err == nil {
+		t.Errorf("missing log dir reported ok=%v err=%v", ok, err)
+	}
+}
+
+func TestLogWritableCheckHonorsContext(t *testing.T) {
+	w := blockingSyncer{release: make(chan struct{})}
+	defer close(w.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	ok, err := LogWritableCheck(w, "")(ctx)
+	if ok || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("hung sync reported ok=%v err=%v, want DeadlineExceeded", ok, err)
+	}
+}
@@ -0,0 +1,36 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// GoroutineCheck reports unhealthy when the number of goroutines exceeds max.
+func GoroutineCheck(max int) func(context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		if n := runtime.NumGoroutine(); n > max {
+			return false, fmt.Errorf("goroutine count %d exceeds limit %d", n, max)
+		}
+		return true, nil
+	}
+}
+
+// MemCheck reports unhealthy when allocated heap bytes exceed maxBytes.
+// runtime.ReadMemStats briefly stops the world, so avoid very short intervals.
+func MemCheck(maxBytes uint64) func(context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		if m.Alloc > maxBytes {
+			return false, fmt.Errorf("heap allocation %d bytes exceeds limit %d", m.Alloc, maxBytes)
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,49 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestGoroutineCheck(t *testing.T) {
+	ctx := context.Background()
+	if ok, err := GoroutineCheck(runtime.NumGoroutine() + 100)(ctx); !ok || err != nil {
+		t.Errorf("under the limit: %v, %v", ok, err)
+	}
+
+	// Park goroutines to push the count over the limit.
+	limit := runtime.NumGoroutine() + 5
+	stop := make(chan struct{})
+	defer close(stop)
+	for i := 0; i < 10; i++ {
+		go func() { <-stop }()
+	}
+	if ok, err := GoroutineCheck(limit)(ctx); ok || err == nil {
+		t.Errorf("over the limit: %v, %v", ok, err)
+	}
+}
+
+func TestMemCheck(t *testing.T) {
+	ctx := context.Background()
+	if ok, err := MemCheck(1 << 62)(ctx); !ok || err != nil {
+		t.Errorf("under the limit: %v, %v", ok, err)
+	}
+	if ok, err := MemCheck(1)(ctx); ok || err == nil {
+		t.Errorf("over the limit: %v, %v", ok, err)
+	}
+}
+
+func TestRuntimeChecksHonorContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for name, check := range map[string]func(context.Context) (bool, error){
+		"goroutines": GoroutineCheck(1 << 30),
+		"memory":     MemCheck(1 << 62),
+	} {
+		if ok, err := check(ctx); ok || !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: %v, %v, want context.Canceled", name, ok, err)
+		}
+	}
+}
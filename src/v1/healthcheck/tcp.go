@@ -0,0 +1,23 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// TCPCheck reports healthy when a TCP connection to addr can be opened
+// within timeout (or the context deadline, if sooner). The connection is
+// closed immediately.
+func TCPCheck(addr string, timeout time.Duration) func(context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		d := net.Dialer{Timeout: timeout}
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return false, fmt.Errorf("failed to connect to %s: %w", addr, err)
+		}
+		conn.Close()
+		return true, nil
+	}
+}
@@ -0,0 +1,61 @@
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTCPCheck(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	accepted := make(chan struct{})
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			conn.Close()
+			close(accepted)
+		}
+	}()
+
+	if ok, err := TCPCheck(ln.Addr().String(), time.Second)(context.Background()); !ok || err != nil {
+		t.Errorf("open port: %v, %v", ok, err)
+	}
+	select {
+	case <-accepted:
+	case <-time.After(time.Second):
+		t.Error("listener never saw the connection")
+	}
+}
+
+func TestTCPCheckClosedPort(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	if ok, err := TCPCheck(addr, time.Second)(context.Background()); ok || err == nil {
+		t.Errorf("closed port: %v, %v", ok, err)
+	}
+}
+
+func TestTCPCheckCanceled(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if ok, err := TCPCheck(ln.Addr().String(), time.Second)(ctx); ok || err == nil {
+		t.Errorf("canceled context: %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AuditHook copies entries carrying a marker field (e.g. audit=true) to a
+// dedicated append-only file. The entry is still written to the main log,
+// marker included.
+type AuditHook struct {
+	// Field is the marker key; entries with this key set to anything but
+	// false are audited.
+	Field string
+	// Formatter renders audit lines; nil uses the logger's formatter.
+	Formatter logrus.Formatter
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewAuditHook opens (or creates) the audit file logFile in logDir.
+func NewAuditHook(logDir, logFile, field string) (*AuditHook, error) {
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory %q: %w", logDir, err)
+	}
+	logPath := filepath.Join(logDir, logFile)
+	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file %q: %w", logPath, err)
+	}
+	return &AuditHook{Field: field, file: f}, nil
+}
+
+func (h *AuditHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *AuditHook) Fire(entry *logrus.Entry) error {
+	v, ok := entry.Data[h.Field]
+	if !ok || v == false {
+		return nil
+	}
+
+	line, err := hookFormatter(entry, h.Formatter).Format(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err = h.file.Write(line)
+	return err
+}
+
+// Close closes the audit file.
+func (h *AuditHook) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.file.Close()
+}
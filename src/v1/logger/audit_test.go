@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditHookWritesOnlyMarkedEntries(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "audit")
+	hook, err := NewAuditHook(dir, "audit.log", "audit")
+	if err != nil {
+		t.Fatalf("NewAuditHook: %v", err)
+	}
+	l, main := jsonLogger()
+	l.AddHook(hook)
+
+	l.WithField("audit", true).Info("role granted")
+	l.Info("routine")
+	l.WithField("audit", false).Info("explicitly not audited")
+	l.WithField("audit", "export").Warn("data exported")
+	if err := hook.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "audit.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit file has %d lines, want 2:\n%s", len(lines), b)
+	}
+	if first := decodeLine(t, []byte(lines[0])); first["msg"] != "role granted" || first["audit"] != true {
+		t.Errorf("first audit line = %v", first)
+	}
+	if second := decodeLine(t, []byte(lines[1])); second["msg"] != "data exported" {
+		t.Errorf("second audit line = %v", second)
+	}
+
+	// The main log keeps every entry, marker included.
+	mainLines := bytes.Split(bytes.TrimSpace(main.Bytes()), []byte("\n"))
+	if len(mainLines) != 4 {
+		t.Fatalf("main log has %d lines, want 4", len(mainLines))
+	}
+	if first := decodeLine(t, mainLines[0]); first["audit"] != true {
+		t.Errorf("marker missing from main log: %v", first)
+	}
+}
+
+func TestAuditHookAppends(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		hook, err := NewAuditHook(dir, "audit.log", "audit")
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, _ := jsonLogger()
+		l.AddHook(hook)
+		l.WithField("audit", true).Info("event")
+		hook.Close()
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "audit.log"))
+	if n := strings.Count(string(b), "\n"); n != 2 {
+		t.Errorf("audit file has %d lines after reopening, want 2", n)
+	}
+}
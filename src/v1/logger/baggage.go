@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"github.com/roboricindustries/go_infr_message/src/v1/messages"
+	"github.com/sirupsen/logrus"
+)
+
+// headKeys are log fields describing the message itself; baggage must not
+// overwrite them.
+var headKeys = map[string]bool{
+	"correlation_id": true,
+	"event_type":     true,
+	"source":         true,
+	"destination":    true,
+}
+
+// WithBaggage returns an entry carrying the head's baggage as fields. Keys
+// that clash with reserved formatter keys or message head fields are
+// prefixed with "baggage.".
+func WithBaggage(l *logrus.Logger, h messages.Head) *logrus.Entry {
+	fields := make(logrus.Fields, len(h.Baggage))
+	for k, v := range h.Baggage {
+		if reservedKeys[k] || headKeys[k] {
+			k = "baggage." + k
+		}
+		fields[k] = v
+	}
+	return l.WithFields(fields)
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/roboricindustries/go_infr_message/src/v1/messages"
+)
+
+func TestBaggagePropagatesToLogs(t *testing.T) {
+	// Producer side.
+	var sent messages.SendingMessage
+	sent.Head.Eventtype = "order.placed"
+	sent.Head.Correlationid = "c-1"
+	sent.Head.SetBaggage("tenant", "acme")
+	sent.Head.SetBaggage("experiment", "checkout-v2")
+	sent.Head.SetBaggage("msg", "shadow")
+	sent.Head.SetBaggage("correlation_id", "spoofed")
+	raw, err := json.Marshal(sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Consumer side.
+	head, err := messages.ParseHead(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, buf := jsonLogger()
+	WithBaggage(l, head).WithField("correlation_id", head.Correlationid).Info("handling")
+
+	out := decodeLine(t, buf.Bytes())
+	if out["tenant"] != "acme" || out["experiment"] != "checkout-v2" {
+		t.Errorf("baggage missing from entry: %v", out)
+	}
+	if out["msg"] != "handling" || out["baggage.msg"] != "shadow" {
+		t.Errorf("reserved key overwritten: msg = %v, baggage.msg = %v", out["msg"], out["baggage.msg"])
+	}
+	if out["correlation_id"] != "c-1" || out["baggage.correlation_id"] != "spoofed" {
+		t.Errorf("head key overwritten: %v", out)
+	}
+}
+
+func TestWithBaggageEmpty(t *testing.T) {
+	l, _ := jsonLogger()
+	if e := WithBaggage(l, messages.Head{}); len(e.Data) != 0 {
+		t.Errorf("fields = %v, want none", e.Data)
+	}
+}
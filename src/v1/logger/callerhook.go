@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logrusPackage prefixes the function names of logrus's own frames.
+var logrusPackage = reflect.TypeOf(logrus.Entry{}).PkgPath() + "."
+
+// CallerHook records the caller only for entries at least as severe as
+// Level, so a logger can run with ReportCaller off and still report lines
+// for warnings and errors without paying for the lookup on every entry:
+//
+//	l.SetReportCaller(false)
+//	l.SetFormatter(&logger.JSONFormatter{LineGate: true, LineLevel: logrus.WarnLevel})
+//	l.AddHook(&logger.CallerHook{Level: logrus.WarnLevel})
+//
+// Like RedactHook it must be registered first, so later hooks see the caller.
+type CallerHook struct {
+	Level logrus.Level
+}
+
+func (h *CallerHook) Levels() []logrus.Level {
+	return logrus.AllLevels[:min(int(h.Level), len(logrus.AllLevels)-1)+1]
+}
+
+func (h *CallerHook) Fire(entry *logrus.Entry) error {
+	if _, ok := entry.Data[facadeCallerKey]; ok || entry.HasCaller() {
+		return nil
+	}
+
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, logrusPackage) {
+			entry.Data[facadeCallerKey] = &frame
+			return nil
+		}
+		if !more {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestCallerHook(t *testing.T) {
+	var buf bytes.Buffer
+	l := logrus.New()
+	l.SetOutput(&buf)
+	l.SetFormatter(&JSONFormatter{LineGate: true, LineLevel: logrus.WarnLevel})
+	l.AddHook(&CallerHook{Level: logrus.WarnLevel})
+
+	_, _, line, _ := runtime.Caller(0)
+	l.Warn("disk almost full")
+	l.WithField("k", "v").Error("disk full")
+	l.Info("routine")
+
+	lines := splitLines(buf.Bytes())
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines:\n%s", len(lines), buf.Bytes())
+	}
+	warn, errLine, info := decodeLine(t, lines[0]), decodeLine(t, lines[1]), decodeLine(t, lines[2])
+	if warn["line"] != float64(line+1) {
+		t.Errorf("warn line = %v, want %d", warn["line"], line+1)
+	}
+	if errLine["line"] != float64(line+2) {
+		t.Errorf("error line = %v, want %d", errLine["line"], line+2)
+	}
+	if _, ok := info["line"]; ok {
+		t.Errorf("info entry has a line: %s", lines[2])
+	}
+	if bytes.Contains(buf.Bytes(), []byte(facadeCallerKey)) {
+		t.Errorf("caller key leaked into the output:\n%s", buf.Bytes())
+	}
+}
+
+func TestCallerHookLevels(t *testing.T) {
+	if got := (&CallerHook{Level: logrus.WarnLevel}).Levels(); len(got) != 4 || got[3] != logrus.WarnLevel {
+		t.Errorf("Levels() = %v, want panic through warn", got)
+	}
+	if got := (&CallerHook{Level: logrus.Level(99)}).Levels(); len(got) != len(logrus.AllLevels) {
+		t.Errorf("Levels() = %v, want all levels", got)
+	}
+}
+
+func TestCallerHookKeepsExistingCaller(t *testing.T) {
+	frame := &runtime.Frame{Line: 7}
+	entry := &logrus.Entry{Logger: logrus.New(), Data: logrus.Fields{facadeCallerKey: frame}}
+	if err := (&CallerHook{}).Fire(entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Data[facadeCallerKey] != frame {
+		t.Errorf("facade caller replaced by %v", entry.Data[facadeCallerKey])
+	}
+}
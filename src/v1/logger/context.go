@@ -0,0 +1,23 @@
+package logger
+
+import (
+	"github.com/roboricindustries/go_infr_message/src/v1/messages"
+	"github.com/sirupsen/logrus"
+)
+
+// WithMessageContext returns an entry carrying the tenant IDs of a sending
+// message's context.
+func WithMessageContext(l *logrus.Logger, ctx messages.MessageContext) *logrus.Entry {
+	return l.WithFields(ctx.Fields())
+}
+
+// WithIncomingMessage returns an entry carrying the tenant IDs of an
+// incoming message body.
+func WithIncomingMessage(l *logrus.Logger, body messages.IncomingMessageBody) *logrus.Entry {
+	ctx := messages.MessageContext{
+		ClientID:   body.ClientID,
+		CompanyID:  body.CompanyID,
+		InstanceID: body.InstanceID,
+	}
+	return l.WithFields(ctx.Fields())
+}
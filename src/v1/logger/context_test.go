@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/roboricindustries/go_infr_message/src/v1/messages"
+	"github.com/sirupsen/logrus"
+)
+
+// jsonLogger returns a logger writing JSONFormatter lines to the buffer.
+func jsonLogger() (*logrus.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := logrus.New()
+	l.SetOutput(&buf)
+	l.SetFormatter(&JSONFormatter{})
+	return l, &buf
+}
+
+func decodeLine(t *testing.T, b []byte) map[string]any {
+	t.Helper()
+	var out map[string]any
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("invalid JSON line %q: %v", b, err)
+	}
+	return out
+}
+
+func TestWithMessageContext(t *testing.T) {
+	l, buf := jsonLogger()
+	WithMessageContext(l, messages.MessageContext{ClientID: 1, CompanyID: 2, InstanceID: 3}).Info("handled")
+
+	out := decodeLine(t, buf.Bytes())
+	if out["client_id"] != float64(1) || out["company_id"] != float64(2) || out["instance_id"] != float64(3) {
+		t.Errorf("entry = %v, want all three IDs", out)
+	}
+}
+
+func TestWithIncomingMessage(t *testing.T) {
+	l, buf := jsonLogger()
+	WithIncomingMessage(l, messages.IncomingMessageBody{ClientID: 4, CompanyID: 5, InstanceID: 6, Message: "hi"}).Info("received")
+
+	out := decodeLine(t, buf.Bytes())
+	if out["client_id"] != float64(4) || out["company_id"] != float64(5) || out["instance_id"] != float64(6) {
+		t.Errorf("entry = %v, want all three IDs", out)
+	}
+	if _, ok := out["message"]; ok {
+		t.Errorf("message text leaked into the fields: %v", out)
+	}
+}
+
+// splitLines splits output into its non-empty lines.
+func splitLines(b []byte) [][]byte {
+	return bytes.Split(bytes.TrimSpace(b), []byte("\n"))
+}
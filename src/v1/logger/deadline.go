@@ -0,0 +1,19 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WithDeadline returns an entry bound to ctx with a "deadline_ms" field
+// holding the time left until ctx's deadline (negative once passed). The
+// field is omitted when ctx has no deadline.
+func WithDeadline(l *logrus.Logger, ctx context.Context) *logrus.Entry {
+	entry := l.WithContext(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		entry = entry.WithField("deadline_ms", time.Until(deadline).Milliseconds())
+	}
+	return entry
+}
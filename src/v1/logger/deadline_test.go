@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithDeadline(t *testing.T) {
+	l, buf := jsonLogger()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	entry := WithDeadline(l, ctx)
+	if entry.Context != ctx {
+		t.Error("entry is not bound to the context")
+	}
+	entry.Info("handling")
+
+	out := decodeLine(t, buf.Bytes())
+	ms, ok := out["deadline_ms"].(float64)
+	if !ok || ms <= 1000 || ms > 2000 {
+		t.Errorf("deadline_ms = %v, want just under 2000", out["deadline_ms"])
+	}
+}
+
+func TestWithDeadlinePassed(t *testing.T) {
+	l, _ := jsonLogger()
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if ms, _ := WithDeadline(l, ctx).Data["deadline_ms"].(int64); ms > -900 {
+		t.Errorf("deadline_ms = %d, want about -1000", ms)
+	}
+}
+
+func TestWithDeadlineWithoutDeadline(t *testing.T) {
+	l, buf := jsonLogger()
+	WithDeadline(l, context.Background()).Info("no deadline")
+	if out := decodeLine(t, buf.Bytes()); out["deadline_ms"] != nil {
+		t.Errorf("deadline_ms set without a deadline: %v", out)
+	}
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// repeatedKey is the field carrying the number of suppressed duplicates on
+// a summary entry.
+const repeatedKey = "repeated"
+
+// dedupCount marks summary entries so they bypass de-duplication.
+type dedupCount int
+
+// DedupFormatter suppresses entries identical to one already written within
+// Window. Entries are identical when their level, message and KeyFields
+// values match. The first occurrence is written immediately; when the window
+// closes a single summary entry with a "repeated" count is logged if any
+// duplicates were dropped.
+//
+// Logrus hooks can't cancel a write, so this wraps the logger's formatter
+// and returns no bytes for suppressed entries. Hooks still see every entry;
+// hooks without their own formatter use the wrapped one. Summaries of
+// Panic and Fatal entries are logged at Error, so they neither panic nor
+// exit from the timer goroutine.
+type DedupFormatter struct {
+	Formatter logrus.Formatter
+	Window    time.Duration
+	KeyFields []string
+
+	mu      sync.Mutex
+	windows map[string]*dedupWindow
+}
+
+type dedupWindow struct {
+	entry      *logrus.Entry
+	caller     *runtime.Frame
+	level      logrus.Level
+	message    string
+	suppressed int
+}
+
+func (f *DedupFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if _, ok := entry.Data[repeatedKey].(dedupCount); ok {
+		return f.Formatter.Format(entry)
+	}
+
+	key := f.key(entry)
+
+	f.mu.Lock()
+	if w, ok := f.windows[key]; ok {
+		w.suppressed++
+		f.mu.Unlock()
+		return nil, nil
+	}
+	if f.windows == nil {
+		f.windows = make(map[string]*dedupWindow)
+	}
+	f.windows[key] = &dedupWindow{
+		entry:   entry.Dup(),
+		caller:  entryCaller(entry),
+		level:   entry.Level,
+		message: entry.Message,
+	}
+	f.mu.Unlock()
+
+	time.AfterFunc(f.Window, func() { f.closeWindow(key) })
+	return f.Formatter.Format(entry)
+}
+
+// Unwrap returns the wrapped formatter.
+func (f *DedupFormatter) Unwrap() logrus.Formatter {
+	return f.Formatter
+}
+
+// closeWindow forgets key and logs a summary if duplicates were dropped.
+func (f *DedupFormatter) closeWindow(key string) {
+	f.mu.Lock()
+	w := f.windows[key]
+	delete(f.windows, key)
+	f.mu.Unlock()
+
+	if w == nil || w.suppressed == 0 {
+		return
+	}
+	summary := w.entry.WithField(repeatedKey, dedupCount(w.suppressed))
+	// Logging from here would report this line; keep the original call site.
+	if w.caller != nil {
+		summary = summary.WithField(facadeCallerKey, w.caller)
+	}
+	summary.Time = time.Time{}
+	summary.Log(max(w.level, logrus.ErrorLevel), w.message)
+}
+
+func (f *DedupFormatter) key(entry *logrus.Entry) string {
+	var b strings.Builder
+	b.WriteString(entry.Level.String())
+	b.WriteByte('|')
+	b.WriteString(entry.Message)
+	for _, k := range f.KeyFields {
+		fmt.Fprintf(&b, "|%s=%v", k, entry.Data[k])
+	}
+	return b.String()
+}
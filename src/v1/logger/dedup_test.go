@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// syncBuffer is a bytes.Buffer safe for writes from timer goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newDedupLogger(out *syncBuffer, keyFields ...string) *logrus.Logger {
+	l := logrus.New()
+	l.SetOutput(out)
+	l.SetFormatter(&DedupFormatter{
+		Formatter: &JSONFormatter{},
+		Window:    20 * time.Millisecond,
+		KeyFields: keyFields,
+	})
+	return l
+}
+
+func TestDedupFormatterSuppressesAndSummarizes(t *testing.T) {
+	var out syncBuffer
+	l := newDedupLogger(&out)
+
+	for i := 0; i < 5; i++ {
+		l.Warn("disk almost full")
+	}
+	l.Info("other")
+
+	time.Sleep(60 * time.Millisecond)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want first, other and summary:\n%s", len(lines), out.String())
+	}
+	if !strings.Contains(lines[2], `"repeated":4`) || !strings.Contains(lines[2], `"msg":"disk almost full"`) {
+		t.Errorf("unexpected summary line: %s", lines[2])
+	}
+}
+
+func TestDedupFormatterSummaryKeepsCaller(t *testing.T) {
+	var out syncBuffer
+	l := newDedupLogger(&out)
+	l.SetReportCaller(true)
+
+	for i := 0; i < 3; i++ {
+		l.Warn("disk almost full")
+	}
+	time.Sleep(60 * time.Millisecond)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want first and summary:\n%s", len(lines), out.String())
+	}
+	first, summary := decodeLine(t, []byte(lines[0])), decodeLine(t, []byte(lines[1]))
+	if first["line"] == float64(0) || summary["line"] != first["line"] {
+		t.Errorf("summary line = %v, want the original call site %v", summary["line"], first["line"])
+	}
+	if _, ok := summary[facadeCallerKey]; ok {
+		t.Errorf("caller key leaked into the output: %s", lines[1])
+	}
+}
+
+func TestDedupFormatterKeyFields(t *testing.T) {
+	var out syncBuffer
+	l := newDedupLogger(&out, "tenant")
+
+	l.WithField("tenant", "a").Info("sync failed")
+	l.WithField("tenant", "b").Info("sync failed")
+	l.WithField("tenant", "a").Info("sync failed")
+
+	if n := strings.Count(out.String(), "\n"); n != 2 {
+		t.Errorf("got %d lines, want one per tenant:\n%s", n, out.String())
+	}
+}
+
+func TestDedupFormatterPanicSummary(t *testing.T) {
+	var out syncBuffer
+	l := newDedupLogger(&out)
+
+	for i := 0; i < 2; i++ {
+		func() {
+			defer func() { _ = recover() }()
+			l.Panic("boom")
+		}()
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if !strings.Contains(out.String(), `"level":"ERROR","line":0,"msg":"boom","repeated":1`) {
+		t.Errorf("expected an Error-level summary, got:\n%s", out.String())
+	}
+}
+
+func TestDedupFormatterWithWriterHook(t *testing.T) {
+	var out, side syncBuffer
+	l := newDedupLogger(&out)
+	l.AddHook(&WriterHook{Writer: &side})
+
+	l.Info("hello")
+	l.Info("hello")
+
+	if n := strings.Count(out.String(), "\n"); n != 1 {
+		t.Errorf("main output has %d lines, want 1:\n%s", n, out.String())
+	}
+	if n := strings.Count(side.String(), "\n"); n != 2 {
+		t.Errorf("hook output has %d lines, want every entry:\n%s", n, side.String())
+	}
+}
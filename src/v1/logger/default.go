@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	defaultMu     sync.Mutex
+	defaultLogger *logrus.Logger
+)
+
+// Init creates the process-wide logger on its first call and returns it.
+// Later calls return the same logger and ignore their arguments, so file
+// handles and hooks are never duplicated by repeated initialization.
+// Services are expected to call Init once at startup and use Default
+// elsewhere.
+func Init(logDir, logFile, logLevel string, hooks ...logrus.Hook) *logrus.Logger {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if defaultLogger == nil {
+		defaultLogger = NewLogger(logDir, logFile, logLevel, hooks...)
+	}
+	return defaultLogger
+}
+
+// Default returns the logger created by Init, or logrus' standard logger if
+// Init hasn't been called yet.
+func Default() *logrus.Logger {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if defaultLogger == nil {
+		return logrus.StandardLogger()
+	}
+	return defaultLogger
+}
+
+// Reset closes the process-wide logger's output and forgets it so Init can
+// run again. It is meant for tests.
+func Reset() {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if defaultLogger == nil {
+		return
+	}
+	if c, ok := defaultLogger.Out.(io.Closer); ok && c != os.Stdout && c != os.Stderr {
+		_ = c.Close()
+	}
+	defaultLogger = nil
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestInitOnce(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+
+	if Default() != logrus.StandardLogger() {
+		t.Error("Default before Init is not the standard logger")
+	}
+
+	hook := new(test.Hook)
+	dir := t.TempDir()
+	first := Init(dir, "app.log", "info", hook)
+	second := Init(dir, "other.log", "debug", hook)
+
+	if first != second || Default() != first {
+		t.Fatal("repeated Init returned a different logger")
+	}
+	if first.GetLevel() != logrus.InfoLevel {
+		t.Errorf("level = %s, want the first Init's info", first.GetLevel())
+	}
+	if n := len(first.Hooks[logrus.InfoLevel]); n != 1 {
+		t.Errorf("%d hooks attached, want 1", n)
+	}
+
+	first.Info("once")
+	if n := len(hook.AllEntries()); n != 1 {
+		t.Errorf("hook fired %d times, want 1", n)
+	}
+}
+
+func TestResetAllowsReinit(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+
+	dir := t.TempDir()
+	first := Init(dir, "app.log", "info")
+	Reset()
+	if Default() != logrus.StandardLogger() {
+		t.Error("Default after Reset is not the standard logger")
+	}
+	if second := Init(dir, "app.log", "warn"); second == first || second.GetLevel() != logrus.WarnLevel {
+		t.Error("Init after Reset did not create a new logger")
+	}
+}
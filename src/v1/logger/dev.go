@@ -0,0 +1,22 @@
+package logger
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewDev creates a colorized, human-readable logger writing to stdout at
+// debug level. It never touches the filesystem and is meant for scripts and
+// local development; use NewLogger for services.
+func NewDev() *logrus.Logger {
+	l := logrus.New()
+	l.SetReportCaller(true)
+	l.SetFormatter(&logrus.TextFormatter{
+		ForceColors:   true,
+		FullTimestamp: true,
+	})
+	l.SetOutput(os.Stdout)
+	l.SetLevel(logrus.DebugLevel)
+	return l
+}
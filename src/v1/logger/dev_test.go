@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestNewDevCreatesNoFiles(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	l := NewDev()
+	if l.Out != os.Stdout || l.GetLevel() != logrus.DebugLevel {
+		t.Errorf("out = %v, level = %s, want stdout at debug", l.Out, l.GetLevel())
+	}
+
+	var buf bytes.Buffer
+	l.SetOutput(&buf)
+	l.WithField("user", "bob").Debug("hello")
+	if got := buf.String(); !strings.Contains(got, "hello") || !strings.Contains(got, "\x1b[") {
+		t.Errorf("output = %q, want a colorized text line", got)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("NewDev created files: %v", entries)
+	}
+}
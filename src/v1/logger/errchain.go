@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrorChainHook adds a "causes" field listing every layer of the entry's
+// error, from outermost to root cause, following errors.Unwrap.
+type ErrorChainHook struct {
+	// MaxDepth bounds the number of layers emitted (default 10), guarding
+	// against cyclic or very deep chains.
+	MaxDepth int
+}
+
+func (h *ErrorChainHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *ErrorChainHook) Fire(entry *logrus.Entry) error {
+	err, ok := entry.Data[logrus.ErrorKey].(error)
+	if !ok || err == nil {
+		return nil
+	}
+
+	maxDepth := h.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = 10
+	}
+
+	var causes []string
+	for ; err != nil && len(causes) < maxDepth; err = errors.Unwrap(err) {
+		causes = append(causes, err.Error())
+	}
+	entry.Data["causes"] = causes
+	return nil
+}
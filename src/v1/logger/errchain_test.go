@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// loopError unwraps to itself, forming a cycle.
+type loopError struct{}
+
+func (e *loopError) Error() string { return "loop" }
+func (e *loopError) Unwrap() error { return e }
+
+func TestErrorChainHook(t *testing.T) {
+	l, buf := jsonLogger()
+	l.AddHook(&ErrorChainHook{})
+
+	root := errors.New("connection refused")
+	err := fmt.Errorf("load user: %w", fmt.Errorf("query db: %w", root))
+	l.WithError(err).Error("request failed")
+
+	out := decodeLine(t, buf.Bytes())
+	want := []any{
+		"load user: query db: connection refused",
+		"query db: connection refused",
+		"connection refused",
+	}
+	if !reflect.DeepEqual(out["causes"], want) {
+		t.Errorf("causes = %v, want %v", out["causes"], want)
+	}
+	if out["error"] != want[0] {
+		t.Errorf("error = %v", out["error"])
+	}
+}
+
+func TestErrorChainHookBoundsDepth(t *testing.T) {
+	l, buf := jsonLogger()
+	l.AddHook(&ErrorChainHook{MaxDepth: 3})
+	l.WithError(&loopError{}).Error("cyclic")
+
+	out := decodeLine(t, buf.Bytes())
+	if causes, _ := out["causes"].([]any); len(causes) != 3 {
+		t.Errorf("causes = %v, want 3 layers", out["causes"])
+	}
+
+	l, buf = jsonLogger()
+	l.AddHook(&ErrorChainHook{})
+	l.WithError(&loopError{}).Error("cyclic")
+	if causes, _ := decodeLine(t, buf.Bytes())["causes"].([]any); len(causes) != 10 {
+		t.Errorf("default depth gave %d layers, want 10", len(causes))
+	}
+}
+
+func TestErrorChainHookWithoutError(t *testing.T) {
+	l, buf := jsonLogger()
+	l.AddHook(&ErrorChainHook{})
+	l.WithField("error", "just a string").Info("no error value")
+
+	if out := decodeLine(t, buf.Bytes()); out["causes"] != nil {
+		t.Errorf("causes added without an error value: %v", out)
+	}
+}
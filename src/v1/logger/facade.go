@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"runtime"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Logger is the minimal structured logging interface accepted by code that
+// shouldn't depend on logrus directly. Use FromLogrus to adapt a logrus
+// logger or entry; tests can supply their own implementation.
+type Logger interface {
+	Info(args ...interface{})
+	Error(args ...interface{})
+	WithField(key string, value interface{}) Logger
+	WithFields(fields map[string]interface{}) Logger
+}
+
+// FromLogrus adapts a *logrus.Logger or *logrus.Entry to Logger.
+func FromLogrus(l logrus.FieldLogger) Logger {
+	return logrusAdapter{l}
+}
+
+type logrusAdapter struct {
+	l logrus.FieldLogger
+}
+
+func (a logrusAdapter) Info(args ...interface{})  { a.withCaller().Info(args...) }
+func (a logrusAdapter) Error(args ...interface{}) { a.withCaller().Error(args...) }
+
+func (a logrusAdapter) WithField(key string, value interface{}) Logger {
+	return logrusAdapter{a.l.WithField(key, value)}
+}
+
+func (a logrusAdapter) WithFields(fields map[string]interface{}) Logger {
+	return logrusAdapter{a.l.WithFields(fields)}
+}
+
+// facadeCallerKey carries the location of a facade call. Logrus would
+// report the adapter itself as the caller, so the formatters in this package
+// use this frame instead and don't write it as a field.
+const facadeCallerKey = "logger.facade_caller"
+
+// withCaller records the caller of the Info or Error method that called it,
+// when the logger reports callers.
+func (a logrusAdapter) withCaller() logrus.FieldLogger {
+	if !reportsCaller(a.l) {
+		return a.l
+	}
+	pc, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return a.l
+	}
+	frame := &runtime.Frame{PC: pc, File: file, Line: line}
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		frame.Function = fn.Name()
+	}
+	return a.l.WithField(facadeCallerKey, frame)
+}
+
+func reportsCaller(l logrus.FieldLogger) bool {
+	switch l := l.(type) {
+	case *logrus.Logger:
+		return l.ReportCaller
+	case *logrus.Entry:
+		return l.Logger.ReportCaller
+	default:
+		return true
+	}
+}
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// mockLogger records facade calls, as a test double for code taking Logger.
+type mockLogger struct {
+	fields map[string]interface{}
+	lines  *[]string
+}
+
+func newMockLogger() mockLogger {
+	return mockLogger{fields: map[string]interface{}{}, lines: new([]string)}
+}
+
+func (m mockLogger) Info(args ...interface{})  { m.record("INFO", args) }
+func (m mockLogger) Error(args ...interface{}) { m.record("ERROR", args) }
+
+func (m mockLogger) WithField(key string, value interface{}) Logger {
+	return m.WithFields(map[string]interface{}{key: value})
+}
+
+func (m mockLogger) WithFields(fields map[string]interface{}) Logger {
+	merged := make(map[string]interface{}, len(m.fields)+len(fields))
+	for k, v := range m.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return mockLogger{fields: merged, lines: m.lines}
+}
+
+func (m mockLogger) record(level string, args []interface{}) {
+	*m.lines = append(*m.lines, fmt.Sprintf("%s %s %v", level, fmt.Sprint(args...), m.fields))
+}
+
+// processOrder stands in for code depending only on the facade.
+func processOrder(log Logger, id int) {
+	log = log.WithField("order_id", id)
+	if id < 0 {
+		log.Error("invalid order")
+		return
+	}
+	log.Info("order processed")
+}
+
+func TestLoggerFacadeMock(t *testing.T) {
+	m := newMockLogger()
+
+	processOrder(m, 7)
+	processOrder(m, -1)
+
+	want := []string{
+		"INFO order processed map[order_id:7]",
+		"ERROR invalid order map[order_id:-1]",
+	}
+	if fmt.Sprint(*m.lines) != fmt.Sprint(want) {
+		t.Errorf("got %q, want %q", *m.lines, want)
+	}
+}
+
+func TestFromLogrus(t *testing.T) {
+	var out bytes.Buffer
+	l := logrus.New()
+	l.SetOutput(&out)
+	l.SetReportCaller(true)
+	l.SetFormatter(&JSONFormatter{})
+
+	_, _, line, _ := runtime.Caller(0)
+	FromLogrus(l).WithFields(map[string]interface{}{"a": 1}).WithField("b", "x").Info("hello")
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out.String(), err)
+	}
+	if got["line"] != float64(line+1) {
+		t.Errorf("line = %v, want the caller's line %d", got["line"], line+1)
+	}
+	if got["a"] != float64(1) || got["b"] != "x" || got["msg"] != "hello" {
+		t.Errorf("unexpected entry %v", got)
+	}
+	if _, ok := got[facadeCallerKey]; ok {
+		t.Errorf("internal caller field leaked into output: %v", got)
+	}
+}
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"slices"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FieldFilter selects which entry fields are shipped off-host. If Include
+// is non-empty only those keys are kept; keys in Exclude are then removed.
+// The zero value keeps every field.
+type FieldFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// apply returns data filtered by f. data itself is never modified, so the
+// local log stays complete.
+func (f FieldFilter) apply(data logrus.Fields) logrus.Fields {
+	if len(f.Include) == 0 && len(f.Exclude) == 0 {
+		return data
+	}
+	out := make(logrus.Fields, len(data))
+	for k, v := range data {
+		if len(f.Include) > 0 && !slices.Contains(f.Include, k) {
+			continue
+		}
+		if slices.Contains(f.Exclude, k) {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// filterEntry returns a shallow copy of entry with its fields filtered by f,
+// or entry itself when f keeps everything.
+func (f FieldFilter) filterEntry(entry *logrus.Entry) *logrus.Entry {
+	if len(f.Include) == 0 && len(f.Exclude) == 0 {
+		return entry
+	}
+	filtered := *entry
+	filtered.Data = f.apply(entry.Data)
+	return &filtered
+}
@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestFieldFilterApply(t *testing.T) {
+	data := logrus.Fields{"user": "bob", "debug_blob": "x", "trace_id": "t-1"}
+	tests := []struct {
+		name   string
+		filter FieldFilter
+		want   logrus.Fields
+	}{
+		{"zero keeps all", FieldFilter{}, data},
+		{"include only", FieldFilter{Include: []string{"user", "missing"}}, logrus.Fields{"user": "bob"}},
+		{"exclude", FieldFilter{Exclude: []string{"debug_blob"}}, logrus.Fields{"user": "bob", "trace_id": "t-1"}},
+		{"exclude wins", FieldFilter{Include: []string{"user", "debug_blob"}, Exclude: []string{"debug_blob"}}, logrus.Fields{"user": "bob"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.apply(data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply = %v, want %v", got, tt.want)
+			}
+			if len(data) != 3 {
+				t.Errorf("apply modified its input: %v", data)
+			}
+		})
+	}
+}
+
+func TestFieldFilterEntry(t *testing.T) {
+	entry := &logrus.Entry{Message: "hi", Data: logrus.Fields{"user": "bob", "debug_blob": "x"}}
+
+	if got := (FieldFilter{}).filterEntry(entry); got != entry {
+		t.Error("zero filter copied the entry")
+	}
+	got := FieldFilter{Exclude: []string{"debug_blob"}}.filterEntry(entry)
+	if got == entry || got.Message != "hi" || len(got.Data) != 1 {
+		t.Errorf("filtered entry = %+v", got)
+	}
+	if len(entry.Data) != 2 {
+		t.Errorf("original entry lost fields: %v", entry.Data)
+	}
+}
+
+func TestGELFFormatterFieldFilter(t *testing.T) {
+	entry := func() *logrus.Entry {
+		return &logrus.Entry{Time: time.Unix(1700000000, 0), Data: logrus.Fields{"user": "bob", "debug_blob": "x"}}
+	}
+
+	out := formatGELF(t, &GELFFormatter{Host: "h", Fields: FieldFilter{Include: []string{"user"}}}, entry())
+	if out["_user"] != "bob" || out["_debug_blob"] != nil {
+		t.Errorf("include-only GELF = %v", out)
+	}
+	out = formatGELF(t, &GELFFormatter{Host: "h", Fields: FieldFilter{Exclude: []string{"debug_blob"}}}, entry())
+	if out["_user"] != "bob" || out["_debug_blob"] != nil {
+		t.Errorf("exclude GELF = %v", out)
+	}
+}
+
+func TestRemoteHookFieldFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter FieldFilter
+	}{
+		{"include only", FieldFilter{Include: []string{"user"}}},
+		{"exclude", FieldFilter{Exclude: []string{"debug_blob"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubEndpoint{}
+			srv := httptest.NewServer(stub)
+			defer srv.Close()
+
+			h := NewRemoteHook(RemoteConfig{Endpoint: srv.URL, FlushInterval: time.Hour, Fields: tt.filter})
+			var local strings.Builder
+			l := newRemoteLogger(h)
+			l.SetOutput(&local)
+			l.WithFields(logrus.Fields{"user": "bob", "debug_blob": "x"}).Info("shipped")
+			h.Close()
+
+			if len(stub.batches) != 1 || len(stub.batches[0]) != 1 {
+				t.Fatalf("batches = %v", stub.batches)
+			}
+			var shipped map[string]any
+			if err := json.Unmarshal([]byte(stub.batches[0][0]), &shipped); err != nil {
+				t.Fatal(err)
+			}
+			if shipped["user"] != "bob" || shipped["debug_blob"] != nil {
+				t.Errorf("shipped line = %v", shipped)
+			}
+			if !strings.Contains(local.String(), `"debug_blob":"x"`) {
+				t.Errorf("local log lost the filtered field: %s", local.String())
+			}
+		})
+	}
+}
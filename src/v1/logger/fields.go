@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogFields is a typed view of the well-known entry fields. Each Has* flag
+// reports whether the field was present with a convertible value.
+//
+// Recognized keys: client_id, company_id, instance_id (unsigned integers,
+// also accepted as signed ints, floats or numeric strings) and
+// correlation_id, event_type, source (strings).
+type LogFields struct {
+	ClientID      uint
+	HasClientID   bool
+	CompanyID     uint
+	HasCompanyID  bool
+	InstanceID    uint
+	HasInstanceID bool
+
+	CorrelationID    string
+	HasCorrelationID bool
+	EventType        string
+	HasEventType     bool
+	Source           string
+	HasSource        bool
+}
+
+// Fields extracts the recognized fields from entry.
+func Fields(entry *logrus.Entry) LogFields {
+	var f LogFields
+	f.ClientID, f.HasClientID = uintField(entry.Data, "client_id")
+	f.CompanyID, f.HasCompanyID = uintField(entry.Data, "company_id")
+	f.InstanceID, f.HasInstanceID = uintField(entry.Data, "instance_id")
+	f.CorrelationID, f.HasCorrelationID = stringField(entry.Data, "correlation_id")
+	f.EventType, f.HasEventType = stringField(entry.Data, "event_type")
+	f.Source, f.HasSource = stringField(entry.Data, "source")
+	return f
+}
+
+func stringField(data logrus.Fields, key string) (string, bool) {
+	s, ok := data[key].(string)
+	return s, ok
+}
+
+func uintField(data logrus.Fields, key string) (uint, bool) {
+	switch v := data[key].(type) {
+	case uint:
+		return v, true
+	case uint8:
+		return uint(v), true
+	case uint16:
+		return uint(v), true
+	case uint32:
+		return uint(v), true
+	case uint64:
+		if uint64(uint(v)) == v {
+			return uint(v), true
+		}
+	case int:
+		if v >= 0 {
+			return uint(v), true
+		}
+	case int32:
+		if v >= 0 {
+			return uint(v), true
+		}
+	case int64:
+		if v >= 0 && uint64(uint(v)) == uint64(v) {
+			return uint(v), true
+		}
+	case float64:
+		if v >= 0 && v <= 1<<53 && v == math.Trunc(v) && uint64(uint(v)) == uint64(v) {
+			return uint(v), true
+		}
+	case string:
+		if n, err := strconv.ParseUint(v, 10, strconv.IntSize); err == nil {
+			return uint(n), true
+		}
+	}
+	return 0, false
+}
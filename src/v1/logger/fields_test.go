@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestFieldsExtractsKnownKeys(t *testing.T) {
+	entry := &logrus.Entry{Data: logrus.Fields{
+		"client_id":      uint(1),
+		"company_id":     "22",
+		"instance_id":    float64(3),
+		"correlation_id": "c-1",
+		"event_type":     "order.placed",
+		"other":          "ignored",
+	}}
+
+	got := Fields(entry)
+	want := LogFields{
+		ClientID: 1, HasClientID: true,
+		CompanyID: 22, HasCompanyID: true,
+		InstanceID: 3, HasInstanceID: true,
+		CorrelationID: "c-1", HasCorrelationID: true,
+		EventType: "order.placed", HasEventType: true,
+	}
+	if got != want {
+		t.Errorf("Fields = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestFieldsMissingAndWrongTyped(t *testing.T) {
+	if got := Fields(&logrus.Entry{Data: logrus.Fields{}}); got != (LogFields{}) {
+		t.Errorf("empty entry = %+v", got)
+	}
+
+	entry := &logrus.Entry{Data: logrus.Fields{
+		"client_id":      -1,
+		"company_id":     "abc",
+		"instance_id":    1.5,
+		"correlation_id": 42,
+		"event_type":     nil,
+		"source":         []string{"api"},
+	}}
+	if got := Fields(entry); got != (LogFields{}) {
+		t.Errorf("wrong-typed fields = %+v, want nothing present", got)
+	}
+}
+
+func TestUintFieldConversions(t *testing.T) {
+	tests := []struct {
+		v    any
+		want uint
+		ok   bool
+	}{
+		{uint8(8), 8, true},
+		{uint16(16), 16, true},
+		{uint32(32), 32, true},
+		{uint64(64), 64, true},
+		{int(7), 7, true},
+		{int32(-7), 0, false},
+		{int64(9), 9, true},
+		{float64(1 << 31), 1 << 31, true},
+		{float64(1<<53) * 2, 0, false},
+		{math.NaN(), 0, false},
+		{" 5", 0, false},
+		{true, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := uintField(logrus.Fields{"k": tt.v}, "k")
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("uintField(%#v) = %d, %v, want %d, %v", tt.v, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// 2^53 is the largest exact float64 integer, which only fits a 64-bit uint.
+	if strconv.IntSize == 64 {
+		exact := uint64(1) << 53
+		if got, ok := uintField(logrus.Fields{"k": float64(exact)}, "k"); !ok || uint64(got) != exact {
+			t.Errorf("uintField(2^53) = %d, %v, want %d, true", got, ok, exact)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package logger
+
+import "github.com/sirupsen/logrus"
+
+// FilterFormatter drops entries for which Keep returns false by producing
+// no output for them, e.g. to silence a noisy component without changing
+// the global level.
+//
+// Logrus hooks can't cancel a write, so filtering happens in the formatter:
+// the logger's own output is filtered, but hooks have already seen the
+// entry by the time it is formatted.
+type FilterFormatter struct {
+	Formatter logrus.Formatter
+	Keep      func(*logrus.Entry) bool
+}
+
+func (f *FilterFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if f.Keep != nil && !f.Keep(entry) {
+		return nil, nil
+	}
+	return f.Formatter.Format(entry)
+}
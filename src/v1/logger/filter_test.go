@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestFilterFormatter(t *testing.T) {
+	l, buf := jsonLogger()
+	l.SetFormatter(&FilterFormatter{
+		Formatter: &JSONFormatter{},
+		Keep: func(e *logrus.Entry) bool {
+			return e.Data["component"] != "healthcheck" || e.Level <= logrus.WarnLevel
+		},
+	})
+
+	l.WithField("component", "healthcheck").Info("probe ok")
+	l.WithField("component", "healthcheck").Warn("probe slow")
+	l.WithField("component", "api").Info("request")
+	l.Info("no component")
+
+	got := buf.String()
+	if strings.Contains(got, "probe ok") {
+		t.Errorf("dropped entry written: %q", got)
+	}
+	for _, msg := range []string{"probe slow", "request", "no component"} {
+		if !strings.Contains(got, `"msg":"`+msg+`"`) {
+			t.Errorf("kept entry %q missing from %q", msg, got)
+		}
+	}
+	if n := len(splitLines(buf.Bytes())); n != 3 {
+		t.Errorf("%d lines written, want 3", n)
+	}
+}
+
+func TestFilterFormatterNilKeep(t *testing.T) {
+	f := &FilterFormatter{Formatter: &JSONFormatter{}}
+	if out := formatJSON(t, f, "kept", nil); out["msg"] != "kept" {
+		t.Errorf("entry = %v", out)
+	}
+}
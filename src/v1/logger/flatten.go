@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/roboricindustries/go_infr_message/src/v1/messages"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxFlattenedFields caps the number of fields produced by FlattenBody.
+var MaxFlattenedFields = 100
+
+// WithFlattenedBody returns an entry carrying the message body as
+// dot-notation fields (see FlattenBody).
+func WithFlattenedBody[T any](l *logrus.Logger, m messages.Message[T], maxDepth int) *logrus.Entry {
+	return l.WithFields(FlattenBody(m, maxDepth))
+}
+
+// FlattenBody converts the message body into "body.<path>" fields, e.g.
+// body.client_id or body.items.0.name, so log platforms can index them.
+// Values nested deeper than maxDepth are kept as a single field. When more
+// than MaxFlattenedFields would be produced the rest are dropped and
+// "body._truncated" is set.
+//
+// Sensitive messages yield only body="[sensitive]", and fields tagged
+// log:"redact" are masked (see messages.RedactForLog). Numbers keep their
+// exact JSON form, so large IDs aren't rounded through float64.
+func FlattenBody[T any](m messages.Message[T], maxDepth int) logrus.Fields {
+	if m.Head.Sensitive {
+		return logrus.Fields{"body": "[sensitive]"}
+	}
+	fields := logrus.Fields{}
+
+	raw, err := json.Marshal(messages.RedactForLog(m.Body))
+	if err != nil {
+		fields["body"] = err.Error()
+		return fields
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		fields["body"] = err.Error()
+		return fields
+	}
+
+	if !flatten(fields, "body", v, maxDepth) {
+		fields["body._truncated"] = true
+	}
+	return fields
+}
+
+// flatten adds v under prefix, recursing into objects and arrays while depth
+// allows. It returns false once the field cap has been reached.
+func flatten(fields logrus.Fields, prefix string, v interface{}, depth int) bool {
+	if depth > 0 {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if !flatten(fields, prefix+"."+k, t[k], depth-1) {
+					return false
+				}
+			}
+			return true
+		case []interface{}:
+			for i, child := range t {
+				if !flatten(fields, prefix+"."+strconv.Itoa(i), child, depth-1) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	if len(fields) >= MaxFlattenedFields {
+		return false
+	}
+	fields[prefix] = v
+	return true
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/roboricindustries/go_infr_message/src/v1/messages"
+)
+
+type flattenBody struct {
+	ClientID uint64         `json:"client_id"`
+	SSN      string         `json:"ssn" log:"redact"`
+	Items    []flattenItem  `json:"items"`
+	Meta     map[string]any `json:"meta"`
+}
+
+type flattenItem struct {
+	Name string `json:"name"`
+}
+
+func TestFlattenBody(t *testing.T) {
+	m := messages.Message[flattenBody]{Body: flattenBody{
+		ClientID: 1<<60 + 1,
+		SSN:      "123-45",
+		Items:    []flattenItem{{Name: "a"}, {Name: "b"}},
+		Meta:     map[string]any{"deep": map[string]any{"x": 1}},
+	}}
+
+	fields := FlattenBody(m, 2)
+
+	if got := fields["body.client_id"]; got != json.Number("1152921504606846977") {
+		t.Errorf("body.client_id = %#v, want exact number", got)
+	}
+	if got := fields["body.ssn"]; got != "[redacted]" {
+		t.Errorf("body.ssn = %v, want [redacted]", got)
+	}
+	if _, ok := fields["body.items.0"]; !ok {
+		t.Errorf("expected body.items.0 to be kept whole at depth 2, got %v", fields)
+	}
+	if _, ok := fields["body.meta.deep"]; !ok {
+		t.Errorf("expected body.meta.deep at depth 2, got %v", fields)
+	}
+}
+
+func TestFlattenBodySensitive(t *testing.T) {
+	m := messages.Message[flattenBody]{Body: flattenBody{SSN: "123-45"}}
+	m.Head.Sensitive = true
+
+	fields := FlattenBody(m, 5)
+
+	if len(fields) != 1 || fields["body"] != "[sensitive]" {
+		t.Errorf("FlattenBody(sensitive) = %v, want only body=[sensitive]", fields)
+	}
+}
+
+func TestFlattenBodyTruncated(t *testing.T) {
+	old := MaxFlattenedFields
+	MaxFlattenedFields = 3
+	defer func() { MaxFlattenedFields = old }()
+
+	items := make([]flattenItem, 10)
+	fields := FlattenBody(messages.Message[flattenBody]{Body: flattenBody{Items: items}}, 5)
+
+	if fields["body._truncated"] != true {
+		t.Errorf("expected body._truncated, got %v", fields)
+	}
+	if len(fields) != MaxFlattenedFields+1 {
+		t.Errorf("got %d fields, want %d", len(fields), MaxFlattenedFields+1)
+	}
+}
@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// referenceFormat is the map-and-Sprintf JSONFormatter that the buffered
+// implementation replaced, kept to check the output stays byte-identical.
+func referenceFormat(f *JSONFormatter, entry *logrus.Entry) []byte {
+	layout := f.TimestampFormat
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+	msg, truncated := truncate(entry.Message, f.MaxMessageBytes)
+	fieldLimit := 0
+	if f.TruncateFields {
+		fieldLimit = f.MaxMessageBytes
+	}
+
+	keys := make([]string, 0, len(entry.Data))
+	for k := range entry.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var fields strings.Builder
+	for _, k := range keys {
+		name := k
+		if reservedKeys[k] {
+			name = "fields." + k
+		}
+		v := entry.Data[k]
+		if s, ok := v.(string); ok {
+			var cut bool
+			v, cut = truncate(s, fieldLimit)
+			truncated = truncated || cut
+		}
+		key, _ := json.Marshal(name)
+		fmt.Fprintf(&fields, ",%s:%s", key, marshalValue(v))
+	}
+	extra := fields.String()
+	if truncated {
+		extra = `,"truncated":true` + extra
+	}
+	if f.GoroutineID {
+		extra = fmt.Sprintf(`,"goid":%d`, goroutineID()) + extra
+	}
+	return []byte(fmt.Sprintf(`{"time":"%s","level":"%s","line":%d,"msg":"%s"%s}%s`,
+		entry.Time.UTC().Format(layout), strings.ToUpper(entry.Level.String()),
+		callerLine(entry), escapeString(msg), extra, f.lineEnding()))
+}
+
+var goldenFields = logrus.Fields{
+	"ascii":    "plain value",
+	"html":     "<a href=\"x\">&</a>",
+	"unicode":  "zürich ✓",
+	"control":  "tab\tnewline\n\x01",
+	"int":      -42,
+	"int64":    int64(1) << 60,
+	"uint":     uint(7),
+	"bool":     true,
+	"float":    3.25,
+	"nil":      nil,
+	"error":    errors.New(`failed: "quoted"`),
+	"struct":   struct{ A int }{A: 1},
+	"map":      map[string]int{"b": 2, "a": 1},
+	"slice":    []string{"x", "y"},
+	"chan":     make(chan int),
+	"duration": 1500 * time.Millisecond,
+	"msg":      "reserved",
+	"long":     strings.Repeat("é", 40),
+}
+
+func TestJSONFormatterMatchesReference(t *testing.T) {
+	formatters := map[string]*JSONFormatter{
+		"default":  {},
+		"truncate": {MaxMessageBytes: 16, TruncateFields: true},
+		"msg only": {MaxMessageBytes: 16},
+		"millis":   {TimestampFormat: RFC3339Milli, LineEnding: "\r\n"},
+		"no eol":   {NoLineEnding: true},
+		"goid":     {GoroutineID: true},
+	}
+	entries := []*logrus.Entry{
+		{Message: "Application started"},
+		{Message: `say "hi" to ünïcode and a much longer message`, Data: goldenFields},
+		{Message: "", Data: logrus.Fields{"k": ""}},
+	}
+	for name, f := range formatters {
+		for i, e := range entries {
+			e.Logger = logrus.New()
+			e.Time = time.Date(2025, 1, 22, 12, 0, 0, 123456789, time.UTC)
+			e.Level = logrus.Level(i + 2)
+
+			got, err := f.Format(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := referenceFormat(f, e); !bytes.Equal(got, want) {
+				t.Errorf("%s, entry %d:\ngot  %s\nwant %s", name, i, got, want)
+			}
+		}
+	}
+}
+
+func benchmarkLogger(f logrus.Formatter) *logrus.Logger {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.SetReportCaller(true)
+	l.SetFormatter(f)
+	return l
+}
+
+var benchFields = logrus.Fields{
+	"client_id":      uint(1234),
+	"correlation_id": "5f0c6a4e-8d1b-4f3a-9c2e-7b6d5a4f3e2d",
+	"event_type":     "order.placed",
+	"attempt":        2,
+	"cached":         false,
+}
+
+func BenchmarkJSONFormatter(b *testing.B) {
+	l := benchmarkLogger(&JSONFormatter{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.WithFields(benchFields).Info("message handled")
+	}
+}
+
+// referenceFormatter adapts referenceFormat for the comparison benchmark.
+type referenceFormatter struct{ JSONFormatter }
+
+func (f *referenceFormatter) Format(e *logrus.Entry) ([]byte, error) {
+	return referenceFormat(&f.JSONFormatter, e), nil
+}
+
+func BenchmarkJSONFormatterReference(b *testing.B) {
+	l := benchmarkLogger(&referenceFormatter{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.WithFields(benchFields).Info("message handled")
+	}
+}
+
+// BenchmarkJSONFormatterLineGate logs info lines with the line field gated
+// at warn. "ungated" and "gated" both keep ReportCaller on, so they differ
+// only in output bytes; "caller hook" turns ReportCaller off and looks up
+// the caller with a CallerHook, which skips info lines entirely.
+func BenchmarkJSONFormatterLineGate(b *testing.B) {
+	gated := &JSONFormatter{LineGate: true, LineLevel: logrus.WarnLevel}
+	for _, bc := range []struct {
+		name   string
+		f      *JSONFormatter
+		caller bool
+		hook   bool
+	}{
+		{"ungated", &JSONFormatter{}, true, false},
+		{"gated", gated, true, false},
+		{"caller hook", gated, false, true},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			l := benchmarkLogger(bc.f)
+			l.SetReportCaller(bc.caller)
+			if bc.hook {
+				l.AddHook(&CallerHook{Level: logrus.WarnLevel})
+			}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				l.WithFields(benchFields).Info("message handled")
+			}
+		})
+	}
+}
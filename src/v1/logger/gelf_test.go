@@ -0,0 +1,149 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func formatGELF(t *testing.T, f *GELFFormatter, entry *logrus.Entry) map[string]any {
+	t.Helper()
+	if entry.Logger == nil {
+		entry.Logger = logrus.New()
+	}
+	b, err := f.Format(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("invalid GELF JSON %q: %v", b, err)
+	}
+	return out
+}
+
+func TestGELFFormatterFields(t *testing.T) {
+	out := formatGELF(t, &GELFFormatter{Host: "web-1"}, &logrus.Entry{
+		Time:    time.Unix(1700000000, 250_000_000),
+		Level:   logrus.WarnLevel,
+		Message: "disk almost full\nused: 97%",
+		Data: logrus.Fields{
+			"user":      "bob",
+			"id":        7,
+			"bad key!":  "x",
+			"cause":     errors.New("quota"),
+			"http.path": "/v1",
+		},
+	})
+
+	want := map[string]any{
+		"version":       "1.1",
+		"host":          "web-1",
+		"short_message": "disk almost full",
+		"full_message":  "disk almost full\nused: 97%",
+		"timestamp":     1700000000.25,
+		"level":         float64(4),
+		"_user":         "bob",
+		"_fields.id":    float64(7),
+		"_bad_key_":     "x",
+		"_cause":        "quota",
+		"_http.path":    "/v1",
+	}
+	for k, v := range want {
+		if out[k] != v {
+			t.Errorf("%s = %#v, want %#v", k, out[k], v)
+		}
+	}
+	if len(out) != len(want) {
+		t.Errorf("unexpected fields in %v", out)
+	}
+}
+
+func TestGELFFormatterSingleLine(t *testing.T) {
+	out := formatGELF(t, &GELFFormatter{}, &logrus.Entry{Level: logrus.InfoLevel, Message: "ok"})
+	if _, ok := out["full_message"]; ok {
+		t.Error("full_message set for a single-line message")
+	}
+	if out["host"] == "" {
+		t.Error("host not defaulted")
+	}
+}
+
+func TestGELFLevelMapping(t *testing.T) {
+	for level, want := range map[logrus.Level]int{
+		logrus.PanicLevel: 0,
+		logrus.FatalLevel: 2,
+		logrus.ErrorLevel: 3,
+		logrus.WarnLevel:  4,
+		logrus.InfoLevel:  6,
+		logrus.DebugLevel: 7,
+		logrus.TraceLevel: 7,
+	} {
+		out := formatGELF(t, &GELFFormatter{Host: "h"}, &logrus.Entry{Level: level})
+		if out["level"] != float64(want) {
+			t.Errorf("%s: level = %v, want %d", level, out["level"], want)
+		}
+	}
+}
+
+func TestGELFUDPWriterChunks(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	w, err := NewGELFUDPWriter(pc.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	read := func() []byte {
+		buf := make([]byte, 65536)
+		_ = pc.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("read datagram: %v", err)
+		}
+		return buf[:n]
+	}
+
+	small := []byte(`{"short_message":"hi"}`)
+	if _, err := w.Write(small); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(); !bytes.Equal(got, small) {
+		t.Errorf("small message = %q", got)
+	}
+
+	large := bytes.Repeat([]byte("x"), 2*gelfChunkSize+100)
+	if n, err := w.Write(large); err != nil || n != len(large) {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+	var joined []byte
+	var id []byte
+	for i := 0; i < 3; i++ {
+		chunk := read()
+		if chunk[0] != 0x1e || chunk[1] != 0x0f || chunk[10] != byte(i) || chunk[11] != 3 {
+			t.Fatalf("chunk %d header = % x", i, chunk[:gelfChunkHdrSize])
+		}
+		if id == nil {
+			id = chunk[2:10]
+		} else if !bytes.Equal(id, chunk[2:10]) {
+			t.Errorf("chunk %d has a different message ID", i)
+		}
+		joined = append(joined, chunk[gelfChunkHdrSize:]...)
+	}
+	if !bytes.Equal(joined, large) {
+		t.Error("reassembled chunks differ from the message")
+	}
+
+	if _, err := w.Write(make([]byte, gelfMaxChunks*gelfChunkSize+1)); err == nil {
+		t.Error("oversized message accepted")
+	}
+}
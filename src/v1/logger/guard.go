@@ -0,0 +1,25 @@
+package logger
+
+import "github.com/sirupsen/logrus"
+
+// IfDebug calls fn only when debug logging is enabled, so expensive log
+// arguments are not built for disabled levels.
+func IfDebug(l *logrus.Logger, fn func(e *logrus.Entry)) {
+	ifLevel(l, logrus.DebugLevel, fn)
+}
+
+// IfInfo calls fn only when info logging is enabled.
+func IfInfo(l *logrus.Logger, fn func(e *logrus.Entry)) {
+	ifLevel(l, logrus.InfoLevel, fn)
+}
+
+// IfWarn calls fn only when warn logging is enabled.
+func IfWarn(l *logrus.Logger, fn func(e *logrus.Entry)) {
+	ifLevel(l, logrus.WarnLevel, fn)
+}
+
+func ifLevel(l *logrus.Logger, level logrus.Level, fn func(e *logrus.Entry)) {
+	if l.IsLevelEnabled(level) {
+		fn(logrus.NewEntry(l))
+	}
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLevelGuards(t *testing.T) {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.SetLevel(logrus.InfoLevel)
+
+	called := map[string]bool{}
+	IfDebug(l, func(*logrus.Entry) { called["debug"] = true })
+	IfInfo(l, func(*logrus.Entry) { called["info"] = true })
+	IfWarn(l, func(e *logrus.Entry) {
+		called["warn"] = true
+		if e.Logger != l {
+			t.Error("entry is not bound to the logger")
+		}
+	})
+
+	if called["debug"] || !called["info"] || !called["warn"] {
+		t.Errorf("called = %v, want info and warn only", called)
+	}
+}
+
+type benchPayload struct {
+	ID    int
+	Items []string
+	Attrs map[string]int
+}
+
+var payload = benchPayload{
+	ID:    42,
+	Items: []string{"a", "b", "c", "d"},
+	Attrs: map[string]int{"x": 1, "y": 2},
+}
+
+func disabledDebugLogger() *logrus.Logger {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.SetLevel(logrus.InfoLevel)
+	return l
+}
+
+// BenchmarkDebugUnguarded builds the argument even though debug is off.
+func BenchmarkDebugUnguarded(b *testing.B) {
+	l := disabledDebugLogger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.WithField("payload", fmt.Sprintf("%+v", payload)).Debug("state")
+	}
+}
+
+func BenchmarkDebugGuarded(b *testing.B) {
+	l := disabledDebugLogger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		IfDebug(l, func(e *logrus.Entry) {
+			e.WithField("payload", fmt.Sprintf("%+v", payload)).Debug("state")
+		})
+	}
+}
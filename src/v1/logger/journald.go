@@ -0,0 +1,6 @@
+package logger
+
+import "errors"
+
+// ErrJournaldUnavailable is returned when the journald socket can't be used.
+var ErrJournaldUnavailable = errors.New("journald is not available")
@@ -0,0 +1,54 @@
+//go:build linux && journald
+
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TestJournaldIntegration logs to the host's journald and reads the entry
+// back with journalctl. Run with: go test -tags journald ./src/v1/logger
+func TestJournaldIntegration(t *testing.T) {
+	if _, err := exec.LookPath("journalctl"); err != nil {
+		t.Skip("journalctl not available")
+	}
+	h, err := NewJournaldHook("logger-integration-test")
+	if err == ErrJournaldUnavailable {
+		t.Skip("journald not running")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.AddHook(h)
+
+	marker := strconv.FormatInt(time.Now().UnixNano(), 36)
+	l.WithField("test_marker", marker).Error("integration entry")
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(200 * time.Millisecond) {
+		out, err := exec.Command("journalctl", "-o", "json", "--no-pager", "TEST_MARKER="+marker).Output()
+		if err != nil || len(strings.TrimSpace(string(out))) == 0 {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(strings.SplitN(string(out), "\n", 2)[0]), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry["MESSAGE"] != "integration entry" || entry["PRIORITY"] != "3" || entry["SYSLOG_IDENTIFIER"] != "logger-integration-test" {
+			t.Errorf("unexpected journal entry %v", entry)
+		}
+		return
+	}
+	t.Fatal("entry did not show up in the journal")
+}
@@ -0,0 +1,165 @@
+//go:build linux
+
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// journaldSocket is the systemd-journald native protocol socket. It is a
+// variable so tests can point the hook at a fake journal.
+var journaldSocket = "/run/systemd/journal/socket"
+
+// journaldReserved are the fields Fire sets itself. Entry fields mapping to
+// one of them are sent with a "FIELDS_" prefix so they can't override them.
+var journaldReserved = map[string]bool{
+	"MESSAGE":           true,
+	"PRIORITY":          true,
+	"SYSLOG_IDENTIFIER": true,
+	"CODE_FILE":         true,
+	"CODE_LINE":         true,
+	"CODE_FUNC":         true,
+}
+
+// JournaldHook forwards entries to systemd-journald using its native
+// protocol. Logrus levels are mapped to syslog priorities and entry fields
+// are sent as upper-cased journald fields. Entries too large for a datagram
+// are passed to journald as a sealed memfd.
+type JournaldHook struct {
+	// Identifier is sent as SYSLOG_IDENTIFIER; defaults to the binary name.
+	Identifier string
+
+	conn *net.UnixConn
+	addr *net.UnixAddr
+}
+
+// NewJournaldHook connects to the local journald socket. It returns
+// ErrJournaldUnavailable when journald isn't running so callers can fall
+// back to file logging.
+func NewJournaldHook(identifier string) (*JournaldHook, error) {
+	if _, err := os.Stat(journaldSocket); err != nil {
+		return nil, ErrJournaldUnavailable
+	}
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journald socket: %w", err)
+	}
+	if identifier == "" {
+		identifier = os.Args[0]
+	}
+	return &JournaldHook{
+		Identifier: identifier,
+		conn:       conn,
+		addr:       &net.UnixAddr{Name: journaldSocket, Net: "unixgram"},
+	}, nil
+}
+
+func (h *JournaldHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *JournaldHook) Fire(entry *logrus.Entry) error {
+	var buf bytes.Buffer
+	writeJournalField(&buf, "MESSAGE", entry.Message)
+	writeJournalField(&buf, "PRIORITY", strconv.Itoa(syslogSeverity(entry.Level)))
+	writeJournalField(&buf, "SYSLOG_IDENTIFIER", h.Identifier)
+	if caller := entryCaller(entry); caller != nil {
+		writeJournalField(&buf, "CODE_FILE", caller.File)
+		writeJournalField(&buf, "CODE_LINE", strconv.Itoa(caller.Line))
+		writeJournalField(&buf, "CODE_FUNC", caller.Function)
+	}
+	for k, v := range entry.Data {
+		if k == facadeCallerKey {
+			continue
+		}
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		name := journaldFieldName(k)
+		if journaldReserved[name] {
+			name = "FIELDS_" + name
+		}
+		writeJournalField(&buf, name, fmt.Sprint(v))
+	}
+
+	return h.send(buf.Bytes())
+}
+
+// send writes one entry as a datagram, falling back to passing a sealed
+// memfd when the entry exceeds the socket's datagram size limit.
+func (h *JournaldHook) send(data []byte) error {
+	_, _, err := h.conn.WriteMsgUnix(data, nil, h.addr)
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return err
+	}
+
+	fd, err := unix.MemfdCreate("logrus-journal", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return fmt.Errorf("failed to create journal memfd: %w", err)
+	}
+	f := os.NewFile(uintptr(fd), "logrus-journal")
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write journal memfd: %w", err)
+	}
+	seals := unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	if _, err := unix.FcntlInt(uintptr(fd), unix.F_ADD_SEALS, seals); err != nil {
+		return fmt.Errorf("failed to seal journal memfd: %w", err)
+	}
+	_, _, err = h.conn.WriteMsgUnix(nil, unix.UnixRights(fd), h.addr)
+	return err
+}
+
+// Close releases the underlying socket.
+func (h *JournaldHook) Close() error {
+	return h.conn.Close()
+}
+
+// journaldFieldName converts a logrus field key into a valid journald field
+// name: upper-case letters, digits and underscores, not starting with "_".
+func journaldFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	name = strings.TrimLeft(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "F" + name
+	}
+	return name
+}
+
+// writeJournalField appends one field in the native protocol format. Values
+// containing newlines use the length-prefixed binary form.
+func writeJournalField(buf *bytes.Buffer, name, value string) {
+	if !strings.ContainsRune(value, '\n') {
+		buf.WriteString(name)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteString(name)
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,182 @@
+//go:build linux
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakeJournal listens where the hook expects journald and decodes the
+// native protocol entries it receives.
+type fakeJournal struct {
+	conn *net.UnixConn
+}
+
+func newFakeJournal(t *testing.T) *fakeJournal {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	old := journaldSocket
+	journaldSocket = path
+	t.Cleanup(func() { journaldSocket = old })
+	return &fakeJournal{conn: conn}
+}
+
+// receive reads one entry, following a passed memfd if there is one.
+func (j *fakeJournal) receive(t *testing.T) (map[string]string, bool) {
+	t.Helper()
+	buf := make([]byte, 1<<16)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := j.conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, viaFD := buf[:n], false
+
+	if oobn > 0 {
+		msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+		if err != nil {
+			t.Fatal(err)
+		}
+		fds, err := syscall.ParseUnixRights(&msgs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := os.NewFile(uintptr(fds[0]), "memfd")
+		defer f.Close()
+		if data, err = io.ReadAll(io.NewSectionReader(f, 0, 1<<30)); err != nil {
+			t.Fatal(err)
+		}
+		viaFD = true
+	}
+	return parseJournalEntry(t, data), viaFD
+}
+
+func parseJournalEntry(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	fields := map[string]string{}
+	r := bufio.NewReader(bytes.NewReader(data))
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			return fields
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if name, value, ok := strings.Cut(line, "="); ok {
+			if _, dup := fields[name]; dup {
+				t.Errorf("duplicate journal field %s", name)
+			}
+			fields[name] = value
+			continue
+		}
+		var size uint64
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			t.Fatal(err)
+		}
+		value := make([]byte, size+1)
+		if _, err := io.ReadFull(r, value); err != nil {
+			t.Fatal(err)
+		}
+		fields[line] = string(value[:size])
+	}
+}
+
+func newJournaldLogger(t *testing.T) *logrus.Logger {
+	t.Helper()
+	h, err := NewJournaldHook("test-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { h.Close() })
+
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.AddHook(h)
+	return l
+}
+
+func TestJournaldHookFields(t *testing.T) {
+	j := newFakeJournal(t)
+	l := newJournaldLogger(t)
+
+	l.WithFields(logrus.Fields{
+		"user-id":  42,
+		"message":  "spoofed",
+		"priority": 0,
+		"stack":    "line1\nline2",
+	}).Warn("disk almost full")
+
+	got, _ := j.receive(t)
+	want := map[string]string{
+		"MESSAGE":           "disk almost full",
+		"PRIORITY":          "4",
+		"SYSLOG_IDENTIFIER": "test-app",
+		"USER_ID":           "42",
+		"FIELDS_MESSAGE":    "spoofed",
+		"FIELDS_PRIORITY":   "0",
+		"STACK":             "line1\nline2",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestJournaldHookLargeEntryUsesMemfd(t *testing.T) {
+	j := newFakeJournal(t)
+	l := newJournaldLogger(t)
+
+	big := strings.Repeat("x", 4<<20)
+	l.WithField("blob", big).Error("large entry")
+
+	got, viaFD := j.receive(t)
+	if !viaFD {
+		t.Fatal("oversized entry was not passed as a file descriptor")
+	}
+	if got["MESSAGE"] != "large entry" || got["BLOB"] != big {
+		t.Errorf("memfd entry decoded to MESSAGE=%q, len(BLOB)=%d", got["MESSAGE"], len(got["BLOB"]))
+	}
+}
+
+func TestNewJournaldHookUnavailable(t *testing.T) {
+	old := journaldSocket
+	journaldSocket = filepath.Join(t.TempDir(), "missing")
+	defer func() { journaldSocket = old }()
+
+	if _, err := NewJournaldHook(""); err != ErrJournaldUnavailable {
+		t.Errorf("err = %v, want ErrJournaldUnavailable", err)
+	}
+}
+
+func TestJournaldFieldName(t *testing.T) {
+	for in, want := range map[string]string{
+		"user.id": "USER_ID",
+		"_hidden": "HIDDEN",
+		"9lives":  "F9LIVES",
+		"___":     "F",
+	} {
+		if got := journaldFieldName(in); got != want {
+			t.Errorf("journaldFieldName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
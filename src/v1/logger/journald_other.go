@@ -0,0 +1,27 @@
+//go:build !linux
+
+package logger
+
+import "github.com/sirupsen/logrus"
+
+// JournaldHook is only functional on Linux.
+type JournaldHook struct {
+	Identifier string
+}
+
+// NewJournaldHook always returns ErrJournaldUnavailable on this platform.
+func NewJournaldHook(identifier string) (*JournaldHook, error) {
+	return nil, ErrJournaldUnavailable
+}
+
+func (h *JournaldHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *JournaldHook) Fire(entry *logrus.Entry) error {
+	return ErrJournaldUnavailable
+}
+
+func (h *JournaldHook) Close() error {
+	return nil
+}
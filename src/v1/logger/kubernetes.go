@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// KubernetesHook adds pod, namespace and node fields read from the
+// downward-API environment variables POD_NAME, POD_NAMESPACE and NODE_NAME.
+// Unset variables are skipped. Pass it to NewLogger to opt in.
+type KubernetesHook struct {
+	fields logrus.Fields
+}
+
+// NewKubernetesHook reads the pod metadata from the environment once.
+func NewKubernetesHook() *KubernetesHook {
+	fields := logrus.Fields{}
+	for key, env := range map[string]string{
+		"pod":       "POD_NAME",
+		"namespace": "POD_NAMESPACE",
+		"node":      "NODE_NAME",
+	} {
+		if v := os.Getenv(env); v != "" {
+			fields[key] = v
+		}
+	}
+	return &KubernetesHook{fields: fields}
+}
+
+func (h *KubernetesHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *KubernetesHook) Fire(entry *logrus.Entry) error {
+	for k, v := range h.fields {
+		if _, ok := entry.Data[k]; !ok {
+			entry.Data[k] = v
+		}
+	}
+	return nil
+}
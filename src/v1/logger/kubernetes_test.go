@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+func TestKubernetesHook(t *testing.T) {
+	t.Setenv("POD_NAME", "api-7d9f")
+	t.Setenv("POD_NAMESPACE", "payments")
+	t.Setenv("NODE_NAME", "")
+
+	hook := NewKubernetesHook()
+	// The environment is read once, at construction.
+	t.Setenv("POD_NAME", "changed")
+
+	var w bufferWriter
+	l := NewLoggerWithWriter(&w, "info", hook)
+	l.Info("started")
+	l.WithField("pod", "explicit").Info("override")
+
+	lines := splitLines(w.Bytes())
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines", len(lines))
+	}
+	first := decodeLine(t, lines[0])
+	if first["pod"] != "api-7d9f" || first["namespace"] != "payments" {
+		t.Errorf("entry = %v, want pod and namespace", first)
+	}
+	if _, ok := first["node"]; ok {
+		t.Errorf("unset NODE_NAME added a node field: %v", first)
+	}
+	if second := decodeLine(t, lines[1]); second["pod"] != "explicit" {
+		t.Errorf("entry field overwritten: pod = %v", second["pod"])
+	}
+}
+
+func TestKubernetesHookOutsideCluster(t *testing.T) {
+	for _, env := range []string{"POD_NAME", "POD_NAMESPACE", "NODE_NAME"} {
+		t.Setenv(env, "")
+		os.Unsetenv(env)
+	}
+	l, buf := jsonLogger()
+	l.AddHook(NewKubernetesHook())
+	l.Info("local")
+
+	out := decodeLine(t, buf.Bytes())
+	for _, k := range []string{"pod", "namespace", "node"} {
+		if _, ok := out[k]; ok {
+			t.Errorf("%s set outside a cluster: %v", k, out)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogfmtFormatter renders entries as logfmt:
+//
+//	time=2025-01-22T12:00:00Z level=INFO line=34 msg="Application started" user=bob
+//
+// Fixed keys come first, followed by entry fields in key order. Values
+// that are empty or contain spaces, quotes, '=' or control characters are
+// quoted.
+type LogfmtFormatter struct{}
+
+func (f *LogfmtFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("time=")
+	b.WriteString(entry.Time.UTC().Format(time.RFC3339Nano))
+	b.WriteString(" level=")
+	b.WriteString(strings.ToUpper(entry.Level.String()))
+	b.WriteString(" line=")
+	b.WriteString(strconv.Itoa(callerLine(entry)))
+	b.WriteString(" msg=")
+	b.WriteString(logfmtValue(entry.Message))
+
+	keys := make([]string, 0, len(entry.Data))
+	for k := range entry.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if k == facadeCallerKey {
+			continue
+		}
+		name := k
+		if reservedKeys[k] {
+			name = "fields." + k
+		}
+		v := entry.Data[k]
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		b.WriteByte(' ')
+		b.WriteString(logfmtKey(name))
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(fmt.Sprint(v)))
+	}
+	b.WriteByte('\n')
+	return []byte(b.String()), nil
+}
+
+// logfmtKey replaces characters that would break key=value parsing.
+func logfmtKey(k string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+		return r
+	}, k)
+}
+
+// logfmtValue quotes s when it can't be written bare.
+func logfmtValue(s string) string {
+	if s == "" {
+		return `""`
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
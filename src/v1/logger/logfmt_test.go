@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func formatLogfmt(t *testing.T, msg string, fields logrus.Fields) string {
+	t.Helper()
+	b, err := (&LogfmtFormatter{}).Format(&logrus.Entry{
+		Logger:  logrus.New(),
+		Data:    fields,
+		Time:    time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC),
+		Level:   logrus.InfoLevel,
+		Message: msg,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestLogfmtFormatter(t *testing.T) {
+	got := formatLogfmt(t, "Application started", logrus.Fields{"user": "bob", "attempt": 2, "err": errors.New("disk full")})
+	want := `time=2025-01-22T12:00:00Z level=INFO line=0 msg="Application started" attempt=2 err="disk full" user=bob` + "\n"
+	if got != want {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+}
+
+func TestLogfmtQuoting(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"plain", "plain"},
+		{"", `""`},
+		{"two words", `"two words"`},
+		{"a=b", `"a=b"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{"line\nbreak", `"line\nbreak"`},
+		{"tab\there", `"tab\there"`},
+		{"del\x7f", `"del\x7f"`},
+		{"ünïcode", "ünïcode"},
+		{"path/to=x y", `"path/to=x y"`},
+	}
+	for _, tt := range tests {
+		got := formatLogfmt(t, "m", logrus.Fields{"v": tt.value})
+		if !strings.HasSuffix(got, " v="+tt.want+"\n") {
+			t.Errorf("value %q formatted as %q, want v=%s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLogfmtKeys(t *testing.T) {
+	got := formatLogfmt(t, "m", logrus.Fields{"b": 1, "a": 2, "odd key=x": 3, "msg": "shadow"})
+	want := `msg=m a=2 b=1 fields.msg=shadow odd_key_x=3` + "\n"
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("got %q, want suffix %q", got, want)
+	}
+}
+
+func TestNewLoggerWithLogfmt(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLoggerWithFormatter(dir, "app.log", "info", &LogfmtFormatter{})
+	l.WithField("user", "bob").Info("hello world")
+
+	b, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := string(b)
+	if !strings.Contains(line, `level=INFO line=`) || !strings.HasSuffix(line, ` msg="hello world" user=bob`+"\n") {
+		t.Errorf("log file = %q", line)
+	}
+	if strings.Contains(line, "line=0 ") {
+		t.Errorf("caller line missing: %q", line)
+	}
+}
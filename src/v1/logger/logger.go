@@ -1,36 +1,278 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/sirupsen/logrus"
 )
 
+// RFC3339Milli is a millisecond-precision RFC 3339 layout for
+// JSONFormatter.TimestampFormat, for backends that reject nanoseconds.
+// Times are always written in UTC, so it ends in "Z".
+const RFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
+
+// truncatedMarker is appended to values cut by MaxMessageBytes.
+const truncatedMarker = "…[truncated]"
+
+// DefaultLevel is used by NewLogger when the requested level can't be parsed.
+var DefaultLevel = logrus.InfoLevel
+
 // JSONFormatter defines your custom JSON log format
-type JSONFormatter struct{}
+type JSONFormatter struct {
+	// MaxMessageBytes truncates msg to this many bytes and adds
+	// "truncated":true. Zero means unlimited.
+	MaxMessageBytes int
+	// TruncateFields applies MaxMessageBytes to string-valued fields too.
+	TruncateFields bool
+	// GoroutineID adds a "goid" field with the emitting goroutine's ID.
+	// It is parsed from runtime.Stack on every entry, which costs a stack
+	// capture per log line; keep it off outside of debugging sessions.
+	GoroutineID bool
+	// LineEnding terminates each line; empty means "\n".
+	LineEnding string
+	// NoLineEnding omits the terminator, for writers that frame lines
+	// themselves. It takes precedence over LineEnding.
+	NoLineEnding bool
+	// TimestampFormat is the layout of the UTC "time" field; empty means
+	// time.RFC3339Nano. See RFC3339Milli.
+	TimestampFormat string
+	// LineGate writes "line" only for entries at least as severe as
+	// LineLevel, e.g. logrus.WarnLevel to drop it from info and debug
+	// lines. This only saves output bytes: logrus still looks up the caller
+	// of every entry while ReportCaller is on. To skip the lookup, turn
+	// ReportCaller off and add a CallerHook at the same level.
+	LineGate  bool
+	LineLevel logrus.Level
+}
 
 func (f *JSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
-	timestamp := entry.Time.UTC().Format(time.RFC3339Nano)
-	level := strings.ToUpper(entry.Level.String())
+	// Logrus hands us a pooled buffer when writing to the logger's output;
+	// hooks formatting entries themselves get a fresh one.
+	b := entry.Buffer
+	if b == nil {
+		b = &bytes.Buffer{}
+	}
 
-	line := 0
-	if entry.HasCaller() {
-		line = entry.Caller.Line
+	layout := f.TimestampFormat
+	if layout == "" {
+		layout = time.RFC3339Nano
 	}
 
-	msg := escapeString(entry.Message)
+	msg, truncated := truncate(entry.Message, f.MaxMessageBytes)
+
+	fieldLimit := 0
+	if f.TruncateFields {
+		fieldLimit = f.MaxMessageBytes
+	}
+	truncated = truncated || fieldsNeedTruncation(entry.Data, fieldLimit)
 
 	// Example JSON structure:
-	// {"time":"2025-01-22T12:00:00.000Z","level":"INFO","line":34,"msg":"Application started"}
-	logLine := fmt.Sprintf(
-		`{"time":"%s","level":"%s","line":%d,"msg":"%s"}`+"\n",
-		timestamp, level, line, msg,
-	)
-	return []byte(logLine), nil
+	// {"time":"2025-01-22T12:00:00.000Z","level":"INFO","line":34,"msg":"Application started","user":"bob"}
+	b.WriteString(`{"time":"`)
+	b.Write(entry.Time.UTC().AppendFormat(b.AvailableBuffer(), layout))
+	b.WriteString(`","level":"`)
+	b.WriteString(levelName(entry.Level))
+	b.WriteByte('"')
+	if !f.LineGate || entry.Level <= f.LineLevel {
+		b.WriteString(`,"line":`)
+		b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(callerLine(entry)), 10))
+	}
+	b.WriteString(`,"msg":"`)
+	b.WriteString(escapeString(msg))
+	b.WriteByte('"')
+	if f.GoroutineID {
+		b.WriteString(`,"goid":`)
+		b.Write(strconv.AppendUint(b.AvailableBuffer(), goroutineID(), 10))
+	}
+	if truncated {
+		b.WriteString(`,"truncated":true`)
+	}
+	writeFields(b, entry.Data, fieldLimit)
+	b.WriteByte('}')
+	b.WriteString(f.lineEnding())
+	return b.Bytes(), nil
+}
+
+// levelNames holds the upper-cased level names written by JSONFormatter.
+var levelNames = func() map[logrus.Level]string {
+	names := make(map[logrus.Level]string, len(logrus.AllLevels))
+	for _, lvl := range logrus.AllLevels {
+		names[lvl] = strings.ToUpper(lvl.String())
+	}
+	return names
+}()
+
+func levelName(level logrus.Level) string {
+	if name, ok := levelNames[level]; ok {
+		return name
+	}
+	return strings.ToUpper(level.String())
+}
+
+func (f *JSONFormatter) lineEnding() string {
+	switch {
+	case f.NoLineEnding:
+		return ""
+	case f.LineEnding == "":
+		return "\n"
+	default:
+		return f.LineEnding
+	}
+}
+
+// callerLine returns the caller's line number, or 0 without caller info.
+func callerLine(entry *logrus.Entry) int {
+	if caller := entryCaller(entry); caller != nil {
+		return caller.Line
+	}
+	return 0
+}
+
+// entryCaller returns the entry's caller, preferring the one recorded by
+// the Logger facade, or nil without caller info.
+func entryCaller(entry *logrus.Entry) *runtime.Frame {
+	if frame, ok := entry.Data[facadeCallerKey].(*runtime.Frame); ok {
+		return frame
+	}
+	if entry.HasCaller() {
+		return entry.Caller
+	}
+	return nil
+}
+
+// truncate cuts s to at most max bytes on a rune boundary and appends the
+// truncation marker. A non-positive max leaves s unchanged.
+func truncate(s string, max int) (string, bool) {
+	if max <= 0 || len(s) <= max {
+		return s, false
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + truncatedMarker, true
+}
+
+// goroutineID parses the current goroutine's ID from the header of its stack
+// trace ("goroutine 18 [running]:"). It returns 0 if parsing fails.
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	field := strings.TrimPrefix(string(buf[:n]), "goroutine ")
+	if i := strings.IndexByte(field, ' '); i > 0 {
+		field = field[:i]
+	}
+	id, err := strconv.ParseUint(field, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// reservedKeys are the keys written by the formatter itself. Entry fields
+// using one of them are emitted with a "fields." prefix instead.
+var reservedKeys = map[string]bool{
+	"time":      true,
+	"level":     true,
+	"line":      true,
+	"msg":       true,
+	"truncated": true,
+	"goid":      true,
+}
+
+// fieldsNeedTruncation reports whether any string field exceeds maxBytes.
+func fieldsNeedTruncation(data logrus.Fields, maxBytes int) bool {
+	if maxBytes <= 0 {
+		return false
+	}
+	for _, v := range data {
+		if str, ok := v.(string); ok && len(str) > maxBytes {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFields renders entry fields as `,"key":value` pairs in key order.
+// String values longer than maxBytes are truncated when maxBytes is positive.
+func writeFields(b *bytes.Buffer, data logrus.Fields, maxBytes int) {
+	if len(data) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if k == facadeCallerKey {
+			continue
+		}
+		b.WriteByte(',')
+		if reservedKeys[k] {
+			writeJSONString(b, "fields."+k)
+		} else {
+			writeJSONString(b, k)
+		}
+		b.WriteByte(':')
+
+		switch v := data[k].(type) {
+		case string:
+			v, _ = truncate(v, maxBytes)
+			writeJSONString(b, v)
+		case int:
+			b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(v), 10))
+		case int64:
+			b.Write(strconv.AppendInt(b.AvailableBuffer(), v, 10))
+		case uint:
+			b.Write(strconv.AppendUint(b.AvailableBuffer(), uint64(v), 10))
+		case bool:
+			b.Write(strconv.AppendBool(b.AvailableBuffer(), v))
+		default:
+			b.Write(marshalValue(v))
+		}
+	}
+}
+
+// writeJSONString writes s as a JSON string, exactly as json.Marshal would,
+// skipping the encoder for plain ASCII.
+func writeJSONString(b *bytes.Buffer, s string) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c > 0x7e || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			out, _ := json.Marshal(s)
+			b.Write(out)
+			return
+		}
+	}
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+}
+
+// marshalValue encodes a field value as JSON, rendering errors by their
+// message and falling back to the %v form for unencodable values.
+func marshalValue(v interface{}) []byte {
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		out, _ = json.Marshal(fmt.Sprint(v))
+	}
+	return out
 }
 
 // escapeString ensures quotes in the message won't break JSON.
@@ -38,41 +280,106 @@ func escapeString(s string) string {
 	return strings.ReplaceAll(s, `"`, `\"`)
 }
 
-// newLogger creates a Logrus logger that writes to the specified
+// NewLogger creates a Logrus logger that writes to the specified
 // log file in logDir, with the given level ("debug", "info", etc.).
-func NewLogger(logDir, logFile, logLevel string) *logrus.Logger {
+// Any hooks passed are attached to the logger.
+func NewLogger(logDir, logFile, logLevel string, hooks ...logrus.Hook) *logrus.Logger {
+	return NewLoggerWithFormatter(logDir, logFile, logLevel, &JSONFormatter{}, hooks...)
+}
+
+// NewLoggerWithFormatter is like NewLogger but uses the given formatter,
+// e.g. &LogfmtFormatter{} instead of the default JSONFormatter.
+func NewLoggerWithFormatter(logDir, logFile, logLevel string, formatter logrus.Formatter, hooks ...logrus.Hook) *logrus.Logger {
+	// Open or create the log file. If that fails, we could fallback to a
+	// default logger, but let's just panic for simplicity.
+	w, err := NewFileWriter(logDir, logFile)
+	if err != nil {
+		panic(err.Error())
+	}
+	return newLogger(w, logLevel, formatter, hooks)
+}
+
+// NewLoggerWithWriter is like NewLogger but writes to the given
+// RotatingWriter instead of a file, e.g. a custom rotation backend.
+func NewLoggerWithWriter(w RotatingWriter, logLevel string, hooks ...logrus.Hook) *logrus.Logger {
+	return newLogger(w, logLevel, &JSONFormatter{}, hooks)
+}
+
+func newLogger(w io.Writer, logLevel string, formatter logrus.Formatter, hooks []logrus.Hook) *logrus.Logger {
 	// 1. Create a new logger
 	l := logrus.New()
 
 	// 2. Enable line numbers
 	l.SetReportCaller(true)
 
-	// 3. Use custom JSON formatter
-	l.SetFormatter(&JSONFormatter{})
+	// 3. Use the requested formatter
+	l.SetFormatter(formatter)
 
-	// 4. Ensure the directory exists
-	if err := os.MkdirAll(logDir, 0o755); err != nil {
-		// If it fails to create the directory, we can fallback to default
-		// or panic/return a default logger. Here, let's just panic for simplicity.
-		panic(fmt.Sprintf("failed to create log directory %q: %v", logDir, err))
-	}
+	// 4. Direct log output to the writer
+	l.SetOutput(w)
 
-	// 5. Open or create the log file
-	logPath := filepath.Join(logDir, logFile)
-	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	// 5. Parse and set log level (default to DefaultLevel if invalid)
+	lvl, err := logrus.ParseLevel(logLevel)
 	if err != nil {
-		panic(fmt.Sprintf("failed to open log file %q: %v", logPath, err))
+		lvl = DefaultLevel
 	}
+	l.SetLevel(lvl)
 
-	// 6. Direct log output to that file
-	l.SetOutput(f)
+	// 6. Attach caller-supplied hooks
+	for _, h := range hooks {
+		l.AddHook(h)
+	}
+
+	// 7. Flush and close the writer and hooks before this logger's Fatal
+	// exits the process. It wraps the logger's own ExitFunc rather than
+	// registering a global logrus exit handler, which could never be removed.
+	exit := l.ExitFunc
+	l.ExitFunc = func(code int) {
+		flushLogger(l)
+		exit(code)
+	}
 
-	// 7. Parse and set log level (default to INFO if invalid)
-	lvl, err := logrus.ParseLevel(logLevel)
 	if err != nil {
-		lvl = logrus.InfoLevel
+		l.Warnf("invalid log level %q, falling back to %s", logLevel, lvl)
 	}
-	l.SetLevel(lvl)
 
 	return l
 }
+
+// flushLogger closes l's hooks, so buffering hooks such as RemoteHook ship
+// what they hold, then syncs and closes its output.
+func flushLogger(l *logrus.Logger) {
+	closeHooks(l.Hooks)
+	flushWriter(l.Out)
+}
+
+// closeHooks closes every hook that is an io.Closer. A hook registered for
+// several levels is closed once.
+func closeHooks(hooks logrus.LevelHooks) {
+	closed := make(map[logrus.Hook]bool)
+	for _, lvl := range logrus.AllLevels {
+		for _, h := range hooks[lvl] {
+			c, ok := h.(io.Closer)
+			if !ok {
+				continue
+			}
+			if reflect.TypeOf(h).Comparable() {
+				if closed[h] {
+					continue
+				}
+				closed[h] = true
+			}
+			_ = c.Close()
+		}
+	}
+}
+
+// flushWriter syncs and closes w when it supports it.
+func flushWriter(w io.Writer) {
+	if s, ok := w.(interface{ Sync() error }); ok {
+		_ = s.Sync()
+	}
+	if c, ok := w.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
@@ -0,0 +1,343 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+// bufferedFileWriter only reaches the file when flushed, like a writer with
+// an internal buffer would.
+type bufferedFileWriter struct {
+	f *os.File
+	b *bufio.Writer
+}
+
+func (w *bufferedFileWriter) Write(p []byte) (int, error) { return w.b.Write(p) }
+func (w *bufferedFileWriter) Rotate() error               { return nil }
+func (w *bufferedFileWriter) Sync() error                 { return w.b.Flush() }
+func (w *bufferedFileWriter) Close() error {
+	if err := w.b.Flush(); err != nil {
+		return err
+	}
+	return w.f.Close()
+}
+
+func TestFatalFlushesWriter(t *testing.T) {
+	if dir := os.Getenv("LOGGER_FATAL_CHILD"); dir != "" {
+		open := func(name string) *bufferedFileWriter {
+			f, err := os.Create(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return &bufferedFileWriter{f: f, b: bufio.NewWriterSize(f, 64<<10)}
+		}
+		remote := NewRemoteHook(RemoteConfig{Endpoint: os.Getenv("LOGGER_FATAL_REMOTE"), FlushInterval: time.Hour})
+		other := NewLoggerWithWriter(open("other.log"), "info")
+		l := NewLoggerWithWriter(open("fatal.log"), "info", remote)
+		other.Info("still buffered")
+		l.Info("queued for remote")
+		l.Fatal("shutting down")
+		return
+	}
+
+	stub := &stubEndpoint{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFatalFlushesWriter$")
+	cmd.Env = append(os.Environ(), "LOGGER_FATAL_CHILD="+dir, "LOGGER_FATAL_REMOTE="+srv.URL)
+	err := cmd.Run()
+
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("child exited with %v, want exit status 1", err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "fatal.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"level":"FATAL"`) || !strings.Contains(string(b), `"msg":"shutting down"`) {
+		t.Errorf("fatal entry was not flushed before exit, file contains %q", b)
+	}
+
+	// Only the exiting logger's writer is flushed and closed.
+	if b, _ := os.ReadFile(filepath.Join(dir, "other.log")); len(b) != 0 {
+		t.Errorf("other logger's writer was flushed by Fatal: %q", b)
+	}
+
+	// The remote hook shipped its queue, fatal line included, before exit.
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	var shipped []string
+	for _, batch := range stub.batches {
+		shipped = append(shipped, batch...)
+	}
+	if len(shipped) != 2 || !strings.Contains(shipped[0], `"msg":"queued for remote"`) || !strings.Contains(shipped[1], `"msg":"shutting down"`) {
+		t.Errorf("remote endpoint received %q, want the queued and fatal lines", shipped)
+	}
+}
+
+// closeCounter is a hook counting its Close calls.
+type closeCounter struct{ closes int }
+
+func (h *closeCounter) Levels() []logrus.Level   { return logrus.AllLevels }
+func (h *closeCounter) Fire(*logrus.Entry) error { return nil }
+func (h *closeCounter) Close() error             { h.closes++; return nil }
+
+func TestCloseHooksClosesEachHookOnce(t *testing.T) {
+	c := &closeCounter{}
+	hooks := logrus.LevelHooks{}
+	hooks.Add(c)
+	hooks.Add(new(test.Hook)) // not a Closer
+
+	closeHooks(hooks)
+	if c.closes != 1 {
+		t.Errorf("hook registered for %d levels closed %d times, want 1", len(logrus.AllLevels), c.closes)
+	}
+}
+
+// formatJSON formats an info entry with msg and fields using f.
+func formatJSON(t *testing.T, f logrus.Formatter, msg string, fields logrus.Fields) map[string]any {
+	t.Helper()
+	entry := &logrus.Entry{
+		Logger:  logrus.New(),
+		Data:    fields,
+		Time:    time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC),
+		Level:   logrus.InfoLevel,
+		Message: msg,
+	}
+	b, err := f.Format(entry)
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Format wrote invalid JSON %q: %v", b, err)
+	}
+	return out
+}
+
+func TestJSONFormatterTruncation(t *testing.T) {
+	tests := []struct {
+		name      string
+		max       int
+		msg       string
+		wantMsg   string
+		truncated bool
+	}{
+		{"unlimited", 0, strings.Repeat("a", 100), strings.Repeat("a", 100), false},
+		{"shorter", 5, "abcd", "abcd", false},
+		{"exact", 5, "abcde", "abcde", false},
+		{"one over", 5, "abcdef", "abcde" + truncatedMarker, true},
+		// "é" is two bytes; cutting inside it backs off to the rune start.
+		{"rune boundary", 5, "abcdé", "abcd" + truncatedMarker, true},
+		{"multibyte fits", 6, "abcdé", "abcdé", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := formatJSON(t, &JSONFormatter{MaxMessageBytes: tt.max}, tt.msg, nil)
+			if out["msg"] != tt.wantMsg {
+				t.Errorf("msg = %q, want %q", out["msg"], tt.wantMsg)
+			}
+			if _, ok := out["truncated"]; ok != tt.truncated {
+				t.Errorf("truncated present = %v, want %v", ok, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestJSONFormatterTruncateFields(t *testing.T) {
+	fields := logrus.Fields{"body": "0123456789", "short": "ok", "n": 1234567890}
+
+	out := formatJSON(t, &JSONFormatter{MaxMessageBytes: 4}, "hi", fields)
+	if out["body"] != "0123456789" || out["truncated"] != nil {
+		t.Errorf("fields truncated without TruncateFields: %v", out)
+	}
+
+	out = formatJSON(t, &JSONFormatter{MaxMessageBytes: 4, TruncateFields: true}, "hi", fields)
+	if out["body"] != "0123"+truncatedMarker || out["short"] != "ok" || out["n"] != float64(1234567890) {
+		t.Errorf("fields = %v", out)
+	}
+	if out["truncated"] != true || out["msg"] != "hi" {
+		t.Errorf("truncated = %v, msg = %v", out["truncated"], out["msg"])
+	}
+
+	out = formatJSON(t, &JSONFormatter{}, "hi", logrus.Fields{"truncated": "user value"})
+	if out["fields.truncated"] != "user value" || out["truncated"] != nil {
+		t.Errorf("reserved key not prefixed: %v", out)
+	}
+}
+
+func TestJSONFormatterGoroutineID(t *testing.T) {
+	f := &JSONFormatter{GoroutineID: true}
+	if out := formatJSON(t, &JSONFormatter{}, "hi", nil); out["goid"] != nil {
+		t.Errorf("goid written by default: %v", out)
+	}
+
+	ids := make(chan any, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			entry := &logrus.Entry{Logger: logrus.New(), Level: logrus.InfoLevel}
+			b, err := f.Format(entry)
+			if err != nil {
+				ids <- err
+				return
+			}
+			var out map[string]any
+			_ = json.Unmarshal(b, &out)
+			ids <- out["goid"]
+		}()
+	}
+	a, b := <-ids, <-ids
+	if _, ok := a.(float64); !ok || a == float64(0) {
+		t.Fatalf("goid = %v, want a goroutine ID", a)
+	}
+	if a == b {
+		t.Errorf("two goroutines logged the same goid %v", a)
+	}
+}
+
+// bufferWriter is an in-memory RotatingWriter.
+type bufferWriter struct {
+	bytes.Buffer
+}
+
+func (w *bufferWriter) Rotate() error { return nil }
+func (w *bufferWriter) Close() error  { return nil }
+
+func TestNewLoggerFallsBackToDefaultLevel(t *testing.T) {
+	defer func(l logrus.Level) { DefaultLevel = l }(DefaultLevel)
+	DefaultLevel = logrus.WarnLevel
+
+	var w bufferWriter
+	l := NewLoggerWithWriter(&w, "verbose")
+	if l.GetLevel() != logrus.WarnLevel {
+		t.Errorf("level = %s, want the DefaultLevel warn", l.GetLevel())
+	}
+	if got := w.String(); !strings.Contains(got, `"level":"WARNING"`) || !strings.Contains(got, `invalid log level \"verbose\"`) {
+		t.Errorf("fallback warning not logged: %q", got)
+	}
+
+	w.Reset()
+	if l := NewLoggerWithWriter(&w, "debug"); l.GetLevel() != logrus.DebugLevel {
+		t.Errorf("level = %s, want debug", l.GetLevel())
+	}
+	if w.Len() != 0 {
+		t.Errorf("valid level logged %q", w.String())
+	}
+}
+
+func TestJSONFormatterLineEnding(t *testing.T) {
+	tests := []struct {
+		name string
+		f    *JSONFormatter
+		want string
+	}{
+		{"default", &JSONFormatter{}, "}\n"},
+		{"crlf", &JSONFormatter{LineEnding: "\r\n"}, "}\r\n"},
+		{"none", &JSONFormatter{NoLineEnding: true}, "}"},
+		{"none wins", &JSONFormatter{LineEnding: "\r\n", NoLineEnding: true}, "}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.f.Format(&logrus.Entry{Logger: logrus.New(), Level: logrus.InfoLevel, Message: "hi"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(b[bytes.LastIndexByte(b, '}'):]); got != tt.want {
+				t.Errorf("line ends in %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLoggerAttachesHooks(t *testing.T) {
+	first, second := new(test.Hook), new(test.Hook)
+	var w bufferWriter
+	l := NewLoggerWithWriter(&w, "info", first, second)
+
+	l.WithField("k", "v").Info("hello")
+	l.Debug("filtered by level")
+
+	for i, h := range []*test.Hook{first, second} {
+		entries := h.AllEntries()
+		if len(entries) != 1 || entries[0].Message != "hello" || entries[0].Data["k"] != "v" {
+			t.Errorf("hook %d saw %d entries: %+v", i, len(entries), entries)
+		}
+	}
+	if !strings.Contains(w.String(), `"msg":"hello"`) {
+		t.Errorf("writer = %q, want the entry written too", w.String())
+	}
+
+	dir := t.TempDir()
+	h := new(test.Hook)
+	NewLogger(dir, "app.log", "info", h).Warn("file logger")
+	if e := h.LastEntry(); e == nil || e.Message != "file logger" {
+		t.Errorf("NewLogger hook saw %+v", e)
+	}
+}
+
+func TestJSONFormatterMillisecondPreset(t *testing.T) {
+	f := &JSONFormatter{TimestampFormat: RFC3339Milli}
+	entry := &logrus.Entry{
+		Logger: logrus.New(),
+		Time:   time.Date(2025, 1, 22, 13, 4, 5, 123456789, time.FixedZone("CET", 3600)),
+		Level:  logrus.InfoLevel,
+	}
+	b, err := f.Format(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(b, []byte(`{"time":"2025-01-22T12:04:05.123Z",`)) {
+		t.Errorf("line = %s, want a millisecond UTC time ending in Z", b)
+	}
+
+	entry.Time = time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)
+	b, _ = f.Format(entry)
+	if !bytes.HasPrefix(b, []byte(`{"time":"2025-01-22T12:00:00.000Z",`)) {
+		t.Errorf("line = %s, want zero milliseconds kept", b)
+	}
+}
+
+func TestJSONFormatterLineGate(t *testing.T) {
+	gated := &JSONFormatter{LineGate: true, LineLevel: logrus.WarnLevel}
+	l := logrus.New()
+	l.SetReportCaller(true)
+	for _, lvl := range logrus.AllLevels {
+		entry := &logrus.Entry{
+			Logger: l,
+			Level:  lvl,
+			Caller: &runtime.Frame{Line: 42},
+		}
+		for _, tt := range []struct {
+			f    *JSONFormatter
+			want bool
+		}{
+			{&JSONFormatter{}, true},
+			{gated, lvl <= logrus.WarnLevel},
+		} {
+			b, err := tt.f.Format(entry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := bytes.Contains(b, []byte(`,"line":42,`)); got != tt.want {
+				t.Errorf("LineGate=%v level %s: line present = %v, want %v: %s", tt.f.LineGate, lvl, got, tt.want, b)
+			}
+			if !json.Valid(b) {
+				t.Errorf("invalid JSON %s", b)
+			}
+		}
+	}
+}
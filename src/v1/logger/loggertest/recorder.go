@@ -0,0 +1,102 @@
+// Package loggertest provides helpers for asserting on log output in tests,
+// kept apart from package logger so services don't link package testing.
+package loggertest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Recorder is a hook that captures entries for assertions in tests. It is
+// safe for concurrent use.
+//
+//	rec := loggertest.NewRecorder(l)
+//	doWork(l)
+//	rec.AssertContains(t, logrus.ErrorLevel, "connection refused")
+type Recorder struct {
+	mu      sync.Mutex
+	entries []*logrus.Entry
+}
+
+// NewRecorder creates a recorder and attaches it to l.
+func NewRecorder(l *logrus.Logger) *Recorder {
+	r := &Recorder{}
+	l.AddHook(r)
+	return r
+}
+
+func (r *Recorder) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (r *Recorder) Fire(entry *logrus.Entry) error {
+	data := make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	e := &logrus.Entry{
+		Logger:  entry.Logger,
+		Data:    data,
+		Time:    entry.Time,
+		Level:   entry.Level,
+		Caller:  entry.Caller,
+		Message: entry.Message,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, e)
+	return nil
+}
+
+// Entries returns a copy of the captured entries.
+func (r *Recorder) Entries() []*logrus.Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*logrus.Entry(nil), r.entries...)
+}
+
+// Count returns the number of captured entries at level.
+func (r *Recorder) Count(level logrus.Level) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, e := range r.entries {
+		if e.Level == level {
+			n++
+		}
+	}
+	return n
+}
+
+// LastEntry returns the most recent entry, or nil if none was captured.
+func (r *Recorder) LastEntry() *logrus.Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) == 0 {
+		return nil
+	}
+	return r.entries[len(r.entries)-1]
+}
+
+// Reset discards the captured entries.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+// AssertContains fails t unless an entry at level has a message containing
+// substr.
+func (r *Recorder) AssertContains(t testing.TB, level logrus.Level, substr string) {
+	t.Helper()
+	for _, e := range r.Entries() {
+		if e.Level == level && strings.Contains(e.Message, substr) {
+			return
+		}
+	}
+	t.Errorf("no %s entry containing %q was logged", level, substr)
+}
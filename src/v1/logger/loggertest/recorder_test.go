@@ -0,0 +1,81 @@
+package loggertest
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakeTB records the failures reported to it.
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.Errorf(format, args...)
+}
+
+func TestRecorder(t *testing.T) {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	rec := NewRecorder(l)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l.WithField("i", i).Info("worker done")
+		}(i)
+	}
+	wg.Wait()
+	l.WithField("host", "db1").Error("connection refused")
+
+	if n := rec.Count(logrus.InfoLevel); n != 10 {
+		t.Errorf("Count(info) = %d, want 10", n)
+	}
+	last := rec.LastEntry()
+	if last == nil || last.Data["host"] != "db1" {
+		t.Errorf("LastEntry() = %v", last)
+	}
+	rec.AssertContains(t, logrus.ErrorLevel, "refused")
+
+	var tb fakeTB
+	rec.AssertContains(&tb, logrus.ErrorLevel, "refused")
+	if len(tb.errors) != 0 {
+		t.Errorf("AssertContains failed for a logged entry: %v", tb.errors)
+	}
+	rec.AssertContains(&tb, logrus.WarnLevel, "refused")
+	if want := `no warning entry containing "refused" was logged`; len(tb.errors) != 1 || tb.errors[0] != want {
+		t.Errorf("AssertContains reported %q, want %q", tb.errors, want)
+	}
+
+	rec.Reset()
+	if n := len(rec.Entries()); n != 0 {
+		t.Errorf("%d entries after Reset, want 0", n)
+	}
+}
+
+func TestRecorderCopiesFields(t *testing.T) {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	rec := NewRecorder(l)
+
+	entry := l.WithField("k", "v")
+	entry.Info("first")
+	entry.Data["k"] = "changed"
+
+	if got := rec.LastEntry().Data["k"]; got != "v" {
+		t.Errorf("recorded field = %v, want the value at log time", got)
+	}
+}
@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/roboricindustries/go_infr_message/src/v1/messages"
+	"github.com/sirupsen/logrus"
+)
+
+// CorrelationIDHeader is the HTTP header used to propagate correlation IDs.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// statusRecorder captures the status code written by the wrapped handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Flush and Hijack forward to the underlying writer for handlers that
+// type-assert http.Flusher or http.Hijacker directly (SSE, websockets).
+func (r *statusRecorder) Flush() {
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T does not support hijacking", r.ResponseWriter)
+	}
+	return h.Hijack()
+}
+
+// HTTPMiddleware takes the correlation ID from the request headers (see
+// messages.ExtractCorrelationID), stores it in the request context and
+// echoes it on the response as X-Correlation-ID. Request
+// start and end are logged with method, path, status and duration fields.
+func HTTPMiddleware(l *logrus.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			correlationID := messages.ExtractCorrelationID(r.Header)
+			w.Header().Set(CorrelationIDHeader, correlationID)
+
+			ctx := messages.WithCorrelationID(r.Context(), correlationID)
+			r = r.WithContext(ctx)
+
+			entry := l.WithFields(logrus.Fields{
+				"correlation_id": correlationID,
+				"method":         r.Method,
+				"path":           r.URL.Path,
+			})
+			entry.Info("request started")
+
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			entry.WithFields(logrus.Fields{
+				"status":      rec.status,
+				"duration_ms": time.Since(start).Milliseconds(),
+			}).Info("request finished")
+		})
+	}
+}
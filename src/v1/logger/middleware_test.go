@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/roboricindustries/go_infr_message/src/v1/messages"
+	"github.com/sirupsen/logrus"
+)
+
+func newMiddlewareLogger(out *bytes.Buffer) *logrus.Logger {
+	l := logrus.New()
+	l.SetOutput(out)
+	l.SetFormatter(&JSONFormatter{})
+	return l
+}
+
+func TestHTTPMiddlewarePropagatesCorrelationID(t *testing.T) {
+	var out bytes.Buffer
+	var seen string
+	h := HTTPMiddleware(newMiddlewareLogger(&out))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = messages.CorrelationIDFromContext(r.Context())
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set(CorrelationIDHeader, "abc-123")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if seen != "abc-123" {
+		t.Errorf("context correlation ID = %q, want abc-123", seen)
+	}
+	if got := rec.Header().Get(CorrelationIDHeader); got != "abc-123" {
+		t.Errorf("response header = %q, want abc-123", got)
+	}
+	logged := out.String()
+	for _, want := range []string{`"msg":"request started"`, `"msg":"request finished"`, `"status":418`, `"path":"/orders"`, `"correlation_id":"abc-123"`} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log output missing %s:\n%s", want, logged)
+		}
+	}
+}
+
+func TestHTTPMiddlewareGeneratesCorrelationID(t *testing.T) {
+	var out bytes.Buffer
+	h := HTTPMiddleware(newMiddlewareLogger(&out))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := rec.Header().Get(CorrelationIDHeader); len(got) != 36 {
+		t.Errorf("generated correlation ID = %q, want a UUID", got)
+	}
+}
+
+func TestHTTPMiddlewareUsesRequestID(t *testing.T) {
+	var out bytes.Buffer
+	h := HTTPMiddleware(newMiddlewareLogger(&out))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "r-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(CorrelationIDHeader); got != "r-1" {
+		t.Errorf("response header = %q, want the X-Request-ID r-1", got)
+	}
+}
+
+func TestHTTPMiddlewareFlush(t *testing.T) {
+	var out bytes.Buffer
+	var flushErr error
+	h := HTTPMiddleware(newMiddlewareLogger(&out))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: 1\n\n"))
+		flushErr = http.NewResponseController(w).Flush()
+		if _, ok := w.(http.Flusher); !ok {
+			t.Error("wrapped writer does not implement http.Flusher")
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	if flushErr != nil {
+		t.Errorf("Flush through ResponseController failed: %v", flushErr)
+	}
+	if !rec.Flushed {
+		t.Error("underlying writer was not flushed")
+	}
+}
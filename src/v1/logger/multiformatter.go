@@ -0,0 +1,24 @@
+package logger
+
+import "github.com/sirupsen/logrus"
+
+// MultiFormatter delegates to a formatter chosen by entry level, falling back
+// to Default (or JSONFormatter when Default is nil).
+//
+// Logrus only applies the logger's formatter to its own output; to format a
+// second sink differently (e.g. text to stdout, JSON to file) attach a
+// WriterHook with its own formatter instead.
+type MultiFormatter struct {
+	Levels  map[logrus.Level]logrus.Formatter
+	Default logrus.Formatter
+}
+
+func (f *MultiFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if lf, ok := f.Levels[entry.Level]; ok {
+		return lf.Format(entry)
+	}
+	if f.Default != nil {
+		return f.Default.Format(entry)
+	}
+	return (&JSONFormatter{}).Format(entry)
+}
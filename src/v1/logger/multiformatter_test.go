@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// staticFormatter formats every entry as its name, to see which one ran.
+type staticFormatter string
+
+func (f staticFormatter) Format(*logrus.Entry) ([]byte, error) {
+	return []byte(f), nil
+}
+
+func TestMultiFormatter(t *testing.T) {
+	f := &MultiFormatter{
+		Levels: map[logrus.Level]logrus.Formatter{
+			logrus.DebugLevel: staticFormatter("debug"),
+			logrus.ErrorLevel: staticFormatter("error"),
+		},
+		Default: staticFormatter("default"),
+	}
+	for level, want := range map[logrus.Level]string{
+		logrus.DebugLevel: "debug",
+		logrus.ErrorLevel: "error",
+		logrus.InfoLevel:  "default",
+		logrus.WarnLevel:  "default",
+	} {
+		b, err := f.Format(&logrus.Entry{Level: level})
+		if err != nil || string(b) != want {
+			t.Errorf("%s: Format = %q, %v, want %q", level, b, err, want)
+		}
+	}
+}
+
+func TestMultiFormatterFallsBackToJSON(t *testing.T) {
+	f := &MultiFormatter{}
+	out := formatJSON(t, f, "hello", nil)
+	if out["msg"] != "hello" || out["level"] != "INFO" {
+		t.Errorf("fallback output = %v", out)
+	}
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"runtime"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MaxGoroutineDumpBytes bounds the goroutine dump captured by LogPanicWithDump.
+// Values below minGoroutineDumpBytes are raised to it.
+var MaxGoroutineDumpBytes = 64 << 10
+
+// minGoroutineDumpBytes is enough for the panicking goroutine's header and
+// first few frames.
+const minGoroutineDumpBytes = 1 << 10
+
+// LogPanicWithDump logs a recovered panic value as a single Error entry with
+// the stacks of all goroutines in the "goroutines" field. The dump is cut at
+// MaxGoroutineDumpBytes.
+func LogPanicWithDump(l *logrus.Logger, recovered any) {
+	buf := make([]byte, max(MaxGoroutineDumpBytes, minGoroutineDumpBytes))
+	n := runtime.Stack(buf, true)
+	dump := string(buf[:n])
+	if n == len(buf) {
+		dump += truncatedMarker
+	}
+
+	l.WithFields(logrus.Fields{
+		"panic":      recovered,
+		"goroutines": dump,
+	}).Error("recovered from panic")
+}
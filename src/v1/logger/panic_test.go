@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLogPanicWithDump(t *testing.T) {
+	defer func(n int) { MaxGoroutineDumpBytes = n }(MaxGoroutineDumpBytes)
+
+	for _, limit := range []int{-1, 0, 10, 64 << 10} {
+		MaxGoroutineDumpBytes = limit
+		l, hook := test.NewNullLogger()
+
+		LogPanicWithDump(l, "boom")
+
+		e := hook.LastEntry()
+		if e == nil || e.Level != logrus.ErrorLevel {
+			t.Fatalf("limit %d: entry = %+v, want one Error entry", limit, e)
+		}
+		if e.Data["panic"] != "boom" {
+			t.Errorf("limit %d: panic = %v", limit, e.Data["panic"])
+		}
+		dump, _ := e.Data["goroutines"].(string)
+		if !strings.HasPrefix(dump, "goroutine ") {
+			t.Errorf("limit %d: dump = %.40q", limit, dump)
+		}
+		if n := len(strings.TrimSuffix(dump, truncatedMarker)); n > max(limit, minGoroutineDumpBytes) {
+			t.Errorf("limit %d: dump is %d bytes", limit, n)
+		}
+	}
+}
+
+func TestLogPanicWithDumpTruncates(t *testing.T) {
+	defer func(n int) { MaxGoroutineDumpBytes = n }(MaxGoroutineDumpBytes)
+	MaxGoroutineDumpBytes = minGoroutineDumpBytes
+
+	// Park enough goroutines that the full dump can't fit.
+	stop := make(chan struct{})
+	defer close(stop)
+	for i := 0; i < 50; i++ {
+		go func() { <-stop }()
+	}
+
+	l, hook := test.NewNullLogger()
+	LogPanicWithDump(l, "boom")
+
+	dump, _ := hook.LastEntry().Data["goroutines"].(string)
+	if !strings.HasSuffix(dump, truncatedMarker) {
+		t.Errorf("dump of %d bytes not marked truncated", len(dump))
+	}
+}
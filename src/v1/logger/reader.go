@@ -0,0 +1,174 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogRecord is one line written by JSONFormatter.
+type LogRecord struct {
+	Time   time.Time
+	Level  logrus.Level
+	Line   int
+	Msg    string
+	Fields map[string]any
+}
+
+// MalformedLine is a line that couldn't be parsed as a LogRecord.
+type MalformedLine struct {
+	Number int
+	Text   string
+	Err    error
+}
+
+// MalformedLinesError reports the lines skipped by ReadLogFile.
+type MalformedLinesError struct {
+	Lines []MalformedLine
+}
+
+func (e *MalformedLinesError) Error() string {
+	return fmt.Sprintf("%d malformed log lines, first at line %d: %v", len(e.Lines), e.Lines[0].Number, e.Lines[0].Err)
+}
+
+// ReadLogFile parses every record in r. Malformed lines are skipped and
+// reported through a *MalformedLinesError returned alongside the records.
+func ReadLogFile(r io.Reader) ([]LogRecord, error) {
+	lr, err := NewLogReader(r)
+	if err != nil {
+		return nil, err
+	}
+	var records []LogRecord
+	for lr.Next() {
+		records = append(records, lr.Record())
+	}
+	if err := lr.Err(); err != nil {
+		return records, err
+	}
+	if m := lr.Malformed(); len(m) > 0 {
+		return records, &MalformedLinesError{Lines: m}
+	}
+	return records, nil
+}
+
+// LogReader streams records from a log file, transparently decompressing
+// gzip-compressed (rotated) files.
+//
+//	for lr.Next() {
+//		rec := lr.Record()
+//	}
+//	if err := lr.Err(); err != nil { ... }
+type LogReader struct {
+	br        *bufio.Reader
+	lineNo    int
+	record    LogRecord
+	err       error
+	malformed []MalformedLine
+}
+
+// NewLogReader creates a reader over r.
+func NewLogReader(r io.Reader) (*LogReader, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open compressed log: %w", err)
+		}
+		br = bufio.NewReader(zr)
+	}
+	return &LogReader{br: br}, nil
+}
+
+// Next advances to the next well-formed record, collecting malformed lines
+// on the way. It returns false at the end of input or on a read error.
+func (lr *LogReader) Next() bool {
+	for lr.err == nil {
+		line, err := lr.br.ReadBytes('\n')
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				lr.err = err
+				return false
+			}
+			lr.err = io.EOF
+		}
+		lr.lineNo++
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		rec, perr := parseLogRecord(line)
+		if perr != nil {
+			lr.malformed = append(lr.malformed, MalformedLine{Number: lr.lineNo, Text: string(line), Err: perr})
+			continue
+		}
+		lr.record = rec
+		return true
+	}
+	return false
+}
+
+// Record returns the record read by the last successful Next.
+func (lr *LogReader) Record() LogRecord {
+	return lr.record
+}
+
+// Err returns the first read error, excluding io.EOF.
+func (lr *LogReader) Err() error {
+	if errors.Is(lr.err, io.EOF) {
+		return nil
+	}
+	return lr.err
+}
+
+// Malformed returns the lines skipped so far.
+func (lr *LogReader) Malformed() []MalformedLine {
+	return lr.malformed
+}
+
+func parseLogRecord(line []byte) (LogRecord, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return LogRecord{}, err
+	}
+
+	var rec LogRecord
+	ts, _ := raw["time"].(string)
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return LogRecord{}, fmt.Errorf("invalid time %q: %w", ts, err)
+	}
+	rec.Time = t
+
+	lvl, _ := raw["level"].(string)
+	if rec.Level, err = logrus.ParseLevel(strings.ToLower(lvl)); err != nil {
+		return LogRecord{}, err
+	}
+
+	if n, ok := raw["line"].(json.Number); ok {
+		line, err := n.Int64()
+		if err != nil {
+			return LogRecord{}, fmt.Errorf("invalid line %q: %w", n, err)
+		}
+		rec.Line = int(line)
+	}
+	rec.Msg, _ = raw["msg"].(string)
+
+	for _, k := range []string{"time", "level", "line", "msg"} {
+		delete(raw, k)
+	}
+	if len(raw) > 0 {
+		rec.Fields = raw
+	}
+	return rec, nil
+}
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// writeLog logs a few entries with JSONFormatter and returns the output.
+func writeLog(t *testing.T) []byte {
+	t.Helper()
+	var w bufferWriter
+	l := NewLoggerWithWriter(&w, "debug")
+	l.WithFields(logrus.Fields{"user": "bob", "id": int64(12345678901234567)}).Info("logged in")
+	l.Warn("disk \"almost\" full")
+	l.WithField("msg", "shadow").Debug("reserved key")
+	return w.Bytes()
+}
+
+func TestReadLogFileRoundTrip(t *testing.T) {
+	records, err := ReadLogFile(bytes.NewReader(writeLog(t)))
+	if err != nil {
+		t.Fatalf("ReadLogFile: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("%d records, want 3", len(records))
+	}
+
+	first := records[0]
+	if first.Level != logrus.InfoLevel || first.Msg != "logged in" || first.Line == 0 || first.Time.IsZero() {
+		t.Errorf("first record = %+v", first)
+	}
+	if first.Fields["user"] != "bob" || first.Fields["id"] != json.Number("12345678901234567") {
+		t.Errorf("first fields = %#v", first.Fields)
+	}
+	if r := records[1]; r.Level != logrus.WarnLevel || r.Msg != `disk "almost" full` || r.Fields != nil {
+		t.Errorf("second record = %+v", r)
+	}
+	if r := records[2]; r.Level != logrus.DebugLevel || r.Fields["fields.msg"] != "shadow" {
+		t.Errorf("third record = %+v", r)
+	}
+}
+
+func TestReadLogFileMalformed(t *testing.T) {
+	input := strings.Join([]string{
+		`{"time":"2025-01-22T12:00:00Z","level":"INFO","line":3,"msg":"ok"}`,
+		`not json`,
+		``,
+		`{"time":"yesterday","level":"INFO","msg":"bad time"}`,
+		`{"time":"2025-01-22T12:00:01Z","level":"LOUD","msg":"bad level"}`,
+		`{"time":"2025-01-22T12:00:02Z","level":"ERROR","line":9,"msg":"also ok"}`,
+	}, "\n")
+
+	records, err := ReadLogFile(strings.NewReader(input))
+	if len(records) != 2 || records[1].Msg != "also ok" || records[1].Line != 9 {
+		t.Errorf("records = %+v", records)
+	}
+	var malformed *MalformedLinesError
+	if !errors.As(err, &malformed) {
+		t.Fatalf("err = %v, want *MalformedLinesError", err)
+	}
+	var numbers []int
+	for _, m := range malformed.Lines {
+		numbers = append(numbers, m.Number)
+	}
+	if len(numbers) != 3 || numbers[0] != 2 || numbers[1] != 4 || numbers[2] != 5 {
+		t.Errorf("malformed lines = %v, want [2 4 5]", numbers)
+	}
+	if malformed.Lines[0].Text != "not json" {
+		t.Errorf("malformed text = %q", malformed.Lines[0].Text)
+	}
+}
+
+func TestLogReaderCompressed(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write(writeLog(t))
+	zw.Close()
+
+	lr, err := NewLogReader(&gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msgs []string
+	for lr.Next() {
+		msgs = append(msgs, lr.Record().Msg)
+	}
+	if err := lr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 3 || msgs[0] != "logged in" || len(lr.Malformed()) != 0 {
+		t.Errorf("msgs = %v, malformed = %v", msgs, lr.Malformed())
+	}
+}
+
+func TestLogReaderCorruptGzip(t *testing.T) {
+	if _, err := NewLogReader(bytes.NewReader([]byte{0x1f, 0x8b, 0x00})); err == nil {
+		t.Error("NewLogReader accepted a corrupt gzip header")
+	}
+}
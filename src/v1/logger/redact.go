@@ -0,0 +1,30 @@
+package logger
+
+import "github.com/sirupsen/logrus"
+
+// loggable is implemented by values that provide a redacted view of
+// themselves for logging, such as messages.Message.
+type loggable interface {
+	Loggable() any
+}
+
+// RedactHook replaces field values implementing Loggable() with their
+// loggable view, so sensitive message bodies never reach the log output.
+//
+// Logrus fires hooks in registration order, so RedactHook only protects
+// hooks added after it. Register it first, e.g. as the first hook passed to
+// NewLogger, or a shipping hook such as RemoteHook sends the raw fields.
+type RedactHook struct{}
+
+func (h *RedactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *RedactHook) Fire(entry *logrus.Entry) error {
+	for k, v := range entry.Data {
+		if lv, ok := v.(loggable); ok {
+			entry.Data[k] = lv.Loggable()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/roboricindustries/go_infr_message/src/v1/messages"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestRedactHookMasksSensitiveMessages(t *testing.T) {
+	l, buf := jsonLogger()
+	l.AddHook(&RedactHook{})
+
+	msg := messages.IncomingMessage{}
+	msg.Head = messages.Head{Eventtype: "patient.updated", Correlationid: "c-1", Sensitive: true}
+	msg.Body.Message = "diagnosis"
+
+	l.WithField("message", msg).WithField("note", "kept").Info("received")
+
+	out := decodeLine(t, buf.Bytes())
+	logged, _ := out["message"].(map[string]any)
+	if logged["body"] != "[sensitive]" {
+		t.Errorf("body = %v, want [sensitive]", logged["body"])
+	}
+	head, _ := logged["head"].(map[string]any)
+	if head["correlation_id"] != "c-1" || head["event_type"] != "patient.updated" {
+		t.Errorf("head = %v, want it kept", head)
+	}
+	if out["note"] != "kept" {
+		t.Errorf("unrelated field changed: %v", out["note"])
+	}
+	if strings.Contains(buf.String(), "diagnosis") {
+		t.Errorf("sensitive body leaked: %s", buf.String())
+	}
+}
+
+func TestRedactHookRunsBeforeLaterHooks(t *testing.T) {
+	msg := messages.IncomingMessage{}
+	msg.Head = messages.Head{Eventtype: "patient.updated", Sensitive: true}
+	msg.Body.Message = "diagnosis"
+
+	var w bufferWriter
+	shipper := new(test.Hook)
+	NewLoggerWithWriter(&w, "info", &RedactHook{}, shipper).WithField("message", msg).Info("received")
+
+	e := shipper.LastEntry()
+	if e == nil {
+		t.Fatal("later hook was not fired")
+	}
+	if _, ok := e.Data["message"].(messages.IncomingMessage); ok {
+		t.Errorf("hook registered after RedactHook saw the raw message: %+v", e.Data["message"])
+	}
+	if strings.Contains(w.String(), "diagnosis") {
+		t.Errorf("sensitive body leaked: %s", w.String())
+	}
+}
@@ -0,0 +1,250 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RemoteConfig configures a RemoteHook. Zero values fall back to defaults.
+type RemoteConfig struct {
+	// Endpoint receives gzip-compressed NDJSON batches via POST.
+	Endpoint string
+	// BatchSize is the number of entries shipped per request (default 100).
+	BatchSize int
+	// FlushInterval ships a partial batch after this long (default 5s).
+	FlushInterval time.Duration
+	// QueueSize bounds the number of buffered entries (default 10*BatchSize).
+	QueueSize int
+	// MaxRetries is the number of retries before a batch is dropped (default
+	// 3); a negative value disables retries.
+	MaxRetries int
+	// RetryBaseDelay is the wait before the first retry; it doubles on each
+	// further attempt (default 200ms).
+	RetryBaseDelay time.Duration
+	// RetryMaxDelay caps the wait between retries (default 30s).
+	RetryMaxDelay time.Duration
+	// RetryJitter randomizes each wait by up to this fraction of it, e.g. 0.2
+	// for ±20%, so many hooks don't retry in lockstep. Zero disables jitter.
+	RetryJitter float64
+	// Client is the HTTP client used for shipping (default http.DefaultClient).
+	Client *http.Client
+	// Timeout bounds each POST, so a hung endpoint can't stall shipping or
+	// Close (default 10s).
+	Timeout time.Duration
+	// Formatter renders entries; nil uses the logger's formatter.
+	Formatter logrus.Formatter
+	// Fields limits which entry fields are shipped.
+	Fields FieldFilter
+}
+
+// RemoteHook batches log lines in the background, gzips them and POSTs them
+// to a remote endpoint. Fire never blocks: entries are dropped when the queue
+// is full, and batches are dropped once retries are exhausted.
+type RemoteHook struct {
+	cfg RemoteConfig
+
+	queue chan []byte
+	quit  chan struct{}
+	wg    sync.WaitGroup
+	once  sync.Once
+
+	droppedEntries atomic.Uint64
+	droppedBatches atomic.Uint64
+}
+
+// NewRemoteHook creates the hook and starts its background shipper.
+func NewRemoteHook(cfg RemoteConfig) *RemoteHook {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 5 * time.Second
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 10 * cfg.BatchSize
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.RetryBaseDelay <= 0 {
+		cfg.RetryBaseDelay = 200 * time.Millisecond
+	}
+	if cfg.RetryMaxDelay <= 0 {
+		cfg.RetryMaxDelay = 30 * time.Second
+	}
+	if cfg.RetryJitter < 0 {
+		cfg.RetryJitter = 0
+	} else if cfg.RetryJitter > 1 {
+		cfg.RetryJitter = 1
+	}
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	h := &RemoteHook{
+		cfg:   cfg,
+		queue: make(chan []byte, cfg.QueueSize),
+		quit:  make(chan struct{}),
+	}
+	h.wg.Add(1)
+	go h.run()
+	return h
+}
+
+func (h *RemoteHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *RemoteHook) Fire(entry *logrus.Entry) error {
+	line, err := hookFormatter(entry, h.cfg.Formatter).Format(h.cfg.Fields.filterEntry(entry))
+	if err != nil {
+		return err
+	}
+
+	select {
+	case h.queue <- line:
+	default:
+		h.droppedEntries.Add(1)
+	}
+	return nil
+}
+
+// DroppedEntries reports entries discarded because the queue was full.
+func (h *RemoteHook) DroppedEntries() uint64 {
+	return h.droppedEntries.Load()
+}
+
+// DroppedBatches reports batches discarded after exhausting retries.
+func (h *RemoteHook) DroppedBatches() uint64 {
+	return h.droppedBatches.Load()
+}
+
+// Close ships any buffered entries and stops the background shipper. Once
+// closing, failed batches are not retried.
+func (h *RemoteHook) Close() error {
+	h.once.Do(func() { close(h.quit) })
+	h.wg.Wait()
+	return nil
+}
+
+func (h *RemoteHook) run() {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(h.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, h.cfg.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := h.ship(batch); err != nil {
+			h.droppedBatches.Add(1)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case line := <-h.queue:
+			batch = append(batch, line)
+			if len(batch) >= h.cfg.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-h.quit:
+			for {
+				select {
+				case line := <-h.queue:
+					batch = append(batch, line)
+					if len(batch) >= h.cfg.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// ship compresses the batch and posts it, retrying with exponential backoff.
+func (h *RemoteHook) ship(batch [][]byte) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	for _, line := range batch {
+		if _, err := zw.Write(line); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	payload := buf.Bytes()
+
+	backoff := h.cfg.RetryBaseDelay
+	var err error
+	for attempt := 0; attempt <= h.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(h.jitter(backoff))
+			select {
+			case <-timer.C:
+			case <-h.quit:
+				timer.Stop()
+				return err
+			}
+			backoff = min(backoff*2, h.cfg.RetryMaxDelay)
+		}
+		if err = h.post(payload); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// jitter spreads d by up to ±RetryJitter of its length.
+func (h *RemoteHook) jitter(d time.Duration) time.Duration {
+	if h.cfg.RetryJitter == 0 {
+		return d
+	}
+	spread := h.cfg.RetryJitter * float64(d)
+	return d + time.Duration((rand.Float64()*2-1)*spread)
+}
+
+func (h *RemoteHook) post(payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.cfg.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := h.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("remote log endpoint returned %s", resp.Status)
+	}
+	return nil
+}
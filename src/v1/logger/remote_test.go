@@ -0,0 +1,267 @@
+package logger
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// stubEndpoint collects the NDJSON lines of every batch it receives.
+type stubEndpoint struct {
+	mu      sync.Mutex
+	batches [][]string
+}
+
+func (s *stubEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Encoding") != "gzip" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+		http.Error(w, "unexpected headers", http.StatusBadRequest)
+		return
+	}
+	zr, err := gzip.NewReader(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var lines []string
+	sc := bufio.NewScanner(zr)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+
+	s.mu.Lock()
+	s.batches = append(s.batches, lines)
+	s.mu.Unlock()
+}
+
+func (s *stubEndpoint) lineCounts() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make([]int, len(s.batches))
+	for i, b := range s.batches {
+		counts[i] = len(b)
+	}
+	return counts
+}
+
+func newRemoteLogger(h *RemoteHook) *logrus.Logger {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.SetFormatter(&JSONFormatter{})
+	l.AddHook(h)
+	return l
+}
+
+func TestRemoteHookBatches(t *testing.T) {
+	stub := &stubEndpoint{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	h := NewRemoteHook(RemoteConfig{Endpoint: srv.URL, BatchSize: 3, FlushInterval: time.Hour})
+	l := newRemoteLogger(h)
+	for i := 0; i < 7; i++ {
+		l.WithField("i", i).Info("event")
+	}
+	h.Close()
+
+	counts := stub.lineCounts()
+	if len(counts) != 3 || counts[0] != 3 || counts[1] != 3 || counts[2] != 1 {
+		t.Errorf("batch sizes = %v, want [3 3 1]", counts)
+	}
+	if h.DroppedBatches() != 0 || h.DroppedEntries() != 0 {
+		t.Errorf("dropped %d batches, %d entries", h.DroppedBatches(), h.DroppedEntries())
+	}
+}
+
+func TestRemoteHookFlushInterval(t *testing.T) {
+	stub := &stubEndpoint{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	h := NewRemoteHook(RemoteConfig{Endpoint: srv.URL, FlushInterval: 20 * time.Millisecond})
+	defer h.Close()
+	newRemoteLogger(h).Info("partial batch")
+
+	deadline := time.Now().Add(time.Second)
+	for len(stub.lineCounts()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("partial batch was not shipped after FlushInterval")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRemoteHookTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	h := NewRemoteHook(RemoteConfig{Endpoint: srv.URL, Timeout: 20 * time.Millisecond, MaxRetries: -1})
+	newRemoteLogger(h).Info("to a hung endpoint")
+
+	done := make(chan struct{})
+	go func() {
+		h.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close blocked on a hung endpoint")
+	}
+	if h.DroppedBatches() != 1 {
+		t.Errorf("DroppedBatches = %d, want 1", h.DroppedBatches())
+	}
+}
+
+func TestRemoteHookCloseInterruptsRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	h := NewRemoteHook(RemoteConfig{
+		Endpoint:       srv.URL,
+		BatchSize:      1,
+		RetryBaseDelay: time.Hour,
+	})
+	newRemoteLogger(h).Info("never accepted")
+	time.Sleep(20 * time.Millisecond) // let the shipper start waiting to retry
+
+	start := time.Now()
+	h.Close()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close took %v while a retry was pending", elapsed)
+	}
+	if h.DroppedBatches() != 1 {
+		t.Errorf("DroppedBatches = %d, want 1", h.DroppedBatches())
+	}
+}
+
+func TestRemoteHookQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+
+	h := NewRemoteHook(RemoteConfig{Endpoint: srv.URL, BatchSize: 1, QueueSize: 2, FlushInterval: time.Hour})
+	l := newRemoteLogger(h)
+	for i := 0; i < 10; i++ {
+		l.Info("burst")
+	}
+	close(release)
+	h.Close()
+
+	if h.DroppedEntries() == 0 {
+		t.Error("expected entries to be dropped with a full queue")
+	}
+}
+
+// flakyEndpoint fails the first failures requests, then hands over to stub.
+type flakyEndpoint struct {
+	stub     *stubEndpoint
+	failures int32
+	calls    atomic.Int32
+}
+
+func (f *flakyEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.calls.Add(1) <= f.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	f.stub.ServeHTTP(w, r)
+}
+
+func TestRemoteHookRetriesUntilSuccess(t *testing.T) {
+	flaky := &flakyEndpoint{stub: &stubEndpoint{}, failures: 2}
+	srv := httptest.NewServer(flaky)
+	defer srv.Close()
+
+	h := NewRemoteHook(RemoteConfig{
+		Endpoint:       srv.URL,
+		BatchSize:      2,
+		FlushInterval:  time.Hour,
+		RetryBaseDelay: 5 * time.Millisecond,
+		RetryJitter:    0.5,
+	})
+	l := newRemoteLogger(h)
+
+	// The shipper retries in the background; logging never waits for it.
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		l.Info("eventually delivered")
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("logging took %v while the endpoint was failing", elapsed)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(flaky.stub.lineCounts()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("batch was not delivered after the endpoint recovered")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	h.Close()
+
+	if got := flaky.calls.Load(); got != 3 {
+		t.Errorf("endpoint called %d times, want 2 failures and 1 success", got)
+	}
+	if counts := flaky.stub.lineCounts(); len(counts) != 1 || counts[0] != 2 {
+		t.Errorf("batch sizes = %v, want [2]", counts)
+	}
+	if h.DroppedBatches() != 0 {
+		t.Errorf("DroppedBatches = %d, want 0", h.DroppedBatches())
+	}
+}
+
+func TestRemoteHookDropsAfterMaxRetries(t *testing.T) {
+	flaky := &flakyEndpoint{stub: &stubEndpoint{}, failures: 1 << 30}
+	srv := httptest.NewServer(flaky)
+	defer srv.Close()
+
+	h := NewRemoteHook(RemoteConfig{
+		Endpoint:       srv.URL,
+		BatchSize:      1,
+		MaxRetries:     2,
+		RetryBaseDelay: time.Millisecond,
+	})
+	newRemoteLogger(h).Info("never accepted")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for h.DroppedBatches() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("batch was not dropped after exhausting retries")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	h.Close()
+
+	if got := flaky.calls.Load(); got != 3 {
+		t.Errorf("endpoint called %d times, want 1 attempt and 2 retries", got)
+	}
+}
+
+func TestRemoteHookJitter(t *testing.T) {
+	h := &RemoteHook{cfg: RemoteConfig{RetryJitter: 0.2}}
+	for i := 0; i < 100; i++ {
+		if d := h.jitter(time.Second); d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("jitter(1s) = %v, want within ±20%%", d)
+		}
+	}
+	h.cfg.RetryJitter = 0
+	if d := h.jitter(time.Second); d != time.Second {
+		t.Errorf("jitter without RetryJitter = %v", d)
+	}
+}
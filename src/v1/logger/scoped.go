@@ -0,0 +1,21 @@
+package logger
+
+import "github.com/sirupsen/logrus"
+
+// ScopedEntry is a log entry whose fields are fixed once created. Child
+// copies the parent's fields into a new scope, so fields added to a child
+// never leak into its parent or siblings. Callers must not modify
+// Entry.Data directly, as that would break this guarantee.
+type ScopedEntry struct {
+	*logrus.Entry
+}
+
+// NewScopedEntry creates a root scope carrying fields.
+func NewScopedEntry(l *logrus.Logger, fields logrus.Fields) *ScopedEntry {
+	return &ScopedEntry{Entry: l.WithFields(fields)}
+}
+
+// Child returns a new scope with the parent's fields plus extra.
+func (s *ScopedEntry) Child(extra logrus.Fields) *ScopedEntry {
+	return &ScopedEntry{Entry: s.Entry.WithFields(extra)}
+}
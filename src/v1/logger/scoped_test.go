@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestScopedEntryChildDoesNotLeak(t *testing.T) {
+	l, buf := jsonLogger()
+	root := NewScopedEntry(l, logrus.Fields{"request_id": "r-1"})
+	child := root.Child(logrus.Fields{"step": "auth"})
+	sibling := root.Child(logrus.Fields{"step": "db", "table": "users"})
+	grandchild := child.Child(logrus.Fields{"user": "bob"})
+
+	if len(root.Data) != 1 {
+		t.Errorf("root fields = %v", root.Data)
+	}
+	if len(child.Data) != 2 || child.Data["step"] != "auth" {
+		t.Errorf("child fields = %v", child.Data)
+	}
+	if sibling.Data["step"] != "db" || len(sibling.Data) != 3 {
+		t.Errorf("sibling fields = %v", sibling.Data)
+	}
+	if _, ok := child.Data["user"]; ok {
+		t.Error("grandchild field leaked into its parent")
+	}
+
+	grandchild.Info("logged in")
+	out := decodeLine(t, buf.Bytes())
+	if out["request_id"] != "r-1" || out["step"] != "auth" || out["user"] != "bob" || out["table"] != nil {
+		t.Errorf("grandchild entry = %v", out)
+	}
+}
+
+func TestScopedEntryChildOverrides(t *testing.T) {
+	l, _ := jsonLogger()
+	root := NewScopedEntry(l, logrus.Fields{"component": "api"})
+	child := root.Child(logrus.Fields{"component": "api.auth"})
+	if root.Data["component"] != "api" || child.Data["component"] != "api.auth" {
+		t.Errorf("root = %v, child = %v", root.Data, child.Data)
+	}
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	shutdownMu      sync.Mutex
+	shutdownHandled = make(map[*logrus.Logger]bool)
+)
+
+// HandleShutdownSignals closes l's hooks and output when one of sigs
+// (default SIGINT and SIGTERM) arrives, so buffering hooks such as
+// RemoteHook ship what they hold, and then re-raises the signal so the
+// process terminates as it would have. It is opt-in and idempotent: later
+// calls for the same logger do nothing.
+//
+// It is meant for services that don't handle these signals themselves;
+// anything logged through l after the signal is lost.
+func HandleShutdownSignals(l *logrus.Logger, sigs ...os.Signal) {
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	if shutdownHandled[l] {
+		return
+	}
+	shutdownHandled[l] = true
+
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	go handleShutdown(l, ch, func(sig os.Signal) {
+		signal.Stop(ch)
+		reraise(sig)
+	})
+}
+
+// handleShutdown waits for the first signal on ch, flushes l and passes the
+// signal to raise.
+func handleShutdown(l *logrus.Logger, ch <-chan os.Signal, raise func(os.Signal)) {
+	sig, ok := <-ch
+	if !ok {
+		return
+	}
+	flushLogger(l)
+	raise(sig)
+}
+
+// reraise delivers sig to the process again, now that the handler is
+// stopped, falling back to exit status 1 where that isn't possible.
+func reraise(sig os.Signal) {
+	if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+		return
+	}
+	os.Exit(1)
+}
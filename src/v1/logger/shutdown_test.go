@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// closingWriter is a RotatingWriter recording whether it was closed.
+type closingWriter struct {
+	bufferWriter
+	closed bool
+}
+
+func (w *closingWriter) Close() error { w.closed = true; return nil }
+
+func TestHandleShutdown(t *testing.T) {
+	stub := &stubEndpoint{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	var w closingWriter
+	remote := NewRemoteHook(RemoteConfig{Endpoint: srv.URL, FlushInterval: time.Hour})
+	l := NewLoggerWithWriter(&w, "info", remote)
+	l.Info("last line before stop")
+
+	ch := make(chan os.Signal, 1)
+	raised := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	go func() {
+		handleShutdown(l, ch, func(sig os.Signal) { raised <- sig })
+		close(done)
+	}()
+	ch <- syscall.SIGTERM
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not finish after the signal")
+	}
+	if sig := <-raised; sig != syscall.SIGTERM {
+		t.Errorf("re-raised %v, want SIGTERM", sig)
+	}
+	if !w.closed {
+		t.Error("writer was not closed")
+	}
+	counts := stub.lineCounts()
+	if len(counts) != 1 || counts[0] != 1 || !strings.Contains(stub.batches[0][0], "last line before stop") {
+		t.Errorf("remote batches = %v, want the queued line shipped", stub.batches)
+	}
+}
+
+func TestHandleShutdownClosedChannel(t *testing.T) {
+	var w closingWriter
+	ch := make(chan os.Signal)
+	close(ch)
+	handleShutdown(NewLoggerWithWriter(&w, "info"), ch, func(os.Signal) { t.Error("raised without a signal") })
+	if w.closed {
+		t.Error("writer closed without a signal")
+	}
+}
+
+func TestHandleShutdownSignalsIdempotent(t *testing.T) {
+	l := logrus.New()
+	t.Cleanup(func() {
+		shutdownMu.Lock()
+		delete(shutdownHandled, l)
+		shutdownMu.Unlock()
+	})
+
+	HandleShutdownSignals(l, os.Interrupt)
+	HandleShutdownSignals(l, os.Interrupt)
+
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	if !shutdownHandled[l] {
+		t.Error("logger not marked as handled")
+	}
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StartupInfo describes the running build. Populate it from variables set
+// at link time, e.g.
+//
+//	var version, commit, buildTime string
+//
+//	go build -ldflags "-X main.version=1.4.0 -X main.commit=$(git rev-parse HEAD) -X main.buildTime=$(date -u +%FT%TZ)"
+type StartupInfo struct {
+	Service   string
+	Version   string
+	Commit    string
+	BuildTime string
+}
+
+// LogStartup emits a single "service started" entry with the build info and
+// the process ID, to correlate deploys in the logs.
+func LogStartup(l *logrus.Logger, info StartupInfo) {
+	l.WithFields(logrus.Fields{
+		"service":    info.Service,
+		"version":    info.Version,
+		"commit":     info.Commit,
+		"build_time": info.BuildTime,
+		"pid":        os.Getpid(),
+	}).Info("service started")
+}
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLogStartup(t *testing.T) {
+	l, buf := jsonLogger()
+	LogStartup(l, StartupInfo{
+		Service:   "orders",
+		Version:   "1.4.0",
+		Commit:    "abc123",
+		BuildTime: "2025-01-22T12:00:00Z",
+	})
+
+	lines := splitLines(buf.Bytes())
+	if len(lines) != 1 {
+		t.Fatalf("%d lines, want 1", len(lines))
+	}
+	out := decodeLine(t, lines[0])
+	want := map[string]any{
+		"level":      "INFO",
+		"msg":        "service started",
+		"service":    "orders",
+		"version":    "1.4.0",
+		"commit":     "abc123",
+		"build_time": "2025-01-22T12:00:00Z",
+		"pid":        float64(os.Getpid()),
+	}
+	for k, v := range want {
+		if out[k] != v {
+			t.Errorf("%s = %v, want %v", k, out[k], v)
+		}
+	}
+}
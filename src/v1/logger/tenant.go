@@ -0,0 +1,186 @@
+package logger
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TenantRouter is an output writer that routes lines carrying a tenant
+// field to per-tenant files (tenant_<id>.log in Dir); other lines go to
+// Main. Set it as the logger's output:
+//
+//	l.SetOutput(&logger.TenantRouter{Main: w, Dir: logDir})
+//
+// Lines are expected to be JSON objects, as written by JSONFormatter; lines
+// that don't parse, and lines whose tenant file can't be opened, go to Main.
+// Tenant IDs are escaped for the file name (see escapeTenantID), so distinct
+// IDs never share a file. Routing happens on write rather than in the
+// formatter so hooks formatting the same entry have no side effects.
+//
+// At most MaxOpen tenant files are kept open; the least recently used one
+// is closed when the limit is reached, and files idle for longer than
+// IdleTimeout are closed on the next write.
+type TenantRouter struct {
+	Main io.Writer
+	Dir  string
+	// TenantFields are checked in order; the first one present selects the
+	// tenant (default firm_id, client_id).
+	TenantFields []string
+	// MaxOpen bounds the number of open tenant files (default 32).
+	MaxOpen int
+	// IdleTimeout closes files not written to for this long; zero disables it.
+	IdleTimeout time.Duration
+
+	mu    sync.Mutex
+	lru   *list.List
+	files map[string]*list.Element
+}
+
+type tenantFile struct {
+	id       string
+	file     *os.File
+	lastUsed time.Time
+}
+
+func (r *TenantRouter) Write(p []byte) (int, error) {
+	id, ok := r.tenant(p)
+	if !ok {
+		return r.Main.Write(p)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.open(id)
+	if err != nil {
+		return r.Main.Write(p)
+	}
+	return f.Write(p)
+}
+
+// Close closes all open tenant files, and Main if it is an io.Closer.
+func (r *TenantRouter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+	if c, ok := r.Main.(io.Closer); ok {
+		firstErr = c.Close()
+	}
+	for id, el := range r.files {
+		if err := el.Value.(*tenantFile).file.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		r.lru.Remove(el)
+		delete(r.files, id)
+	}
+	return firstErr
+}
+
+// tenant returns the escaped tenant ID of a JSON log line.
+func (r *TenantRouter) tenant(line []byte) (string, bool) {
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(line, &data); err != nil {
+		return "", false
+	}
+
+	fields := r.TenantFields
+	if len(fields) == 0 {
+		fields = []string{"firm_id", "client_id"}
+	}
+	for _, k := range fields {
+		raw, ok := data[k]
+		if !ok {
+			continue
+		}
+		var id string
+		if err := json.Unmarshal(raw, &id); err != nil {
+			id = string(raw)
+		}
+		return escapeTenantID(id), id != ""
+	}
+	return "", false
+}
+
+// open returns the file for tenant id, opening it and evicting idle or
+// least recently used files as needed. r.mu must be held.
+func (r *TenantRouter) open(id string) (*os.File, error) {
+	if r.files == nil {
+		r.files = make(map[string]*list.Element)
+		r.lru = list.New()
+	}
+	now := time.Now()
+	r.closeIdle(now)
+
+	if el, ok := r.files[id]; ok {
+		tf := el.Value.(*tenantFile)
+		tf.lastUsed = now
+		r.lru.MoveToFront(el)
+		return tf.file, nil
+	}
+
+	maxOpen := r.MaxOpen
+	if maxOpen <= 0 {
+		maxOpen = 32
+	}
+	for r.lru.Len() >= maxOpen {
+		r.evict(r.lru.Back())
+	}
+
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create tenant log directory %q: %w", r.Dir, err)
+	}
+	path := filepath.Join(r.Dir, "tenant_"+id+".log")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tenant log file %q: %w", path, err)
+	}
+	r.files[id] = r.lru.PushFront(&tenantFile{id: id, file: f, lastUsed: now})
+	return f, nil
+}
+
+func (r *TenantRouter) closeIdle(now time.Time) {
+	if r.IdleTimeout <= 0 {
+		return
+	}
+	for el := r.lru.Back(); el != nil; el = r.lru.Back() {
+		if now.Sub(el.Value.(*tenantFile).lastUsed) < r.IdleTimeout {
+			return
+		}
+		r.evict(el)
+	}
+}
+
+func (r *TenantRouter) evict(el *list.Element) {
+	tf := el.Value.(*tenantFile)
+	_ = tf.file.Close()
+	r.lru.Remove(el)
+	delete(r.files, tf.id)
+}
+
+// escapeTenantID makes id safe for a file name. Letters, digits, '-' and
+// '_' are kept; every other byte is written as %XX, so the mapping is
+// reversible and "acme.corp" and "acmecorp" get different files.
+func escapeTenantID(id string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0xF])
+		}
+	}
+	return b.String()
+}
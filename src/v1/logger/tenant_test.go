@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTenantLogger(t *testing.T, main *bytes.Buffer, maxOpen int) (*logrus.Logger, *TenantRouter) {
+	t.Helper()
+	r := &TenantRouter{Main: main, Dir: t.TempDir(), MaxOpen: maxOpen}
+	t.Cleanup(func() { r.Close() })
+
+	l := logrus.New()
+	l.SetFormatter(&JSONFormatter{})
+	l.SetOutput(r)
+	return l, r
+}
+
+func readTenantFile(t *testing.T, r *TenantRouter, id string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(r.Dir, "tenant_"+id+".log"))
+	if err != nil {
+		t.Fatalf("failed to read tenant %s log: %v", id, err)
+	}
+	return string(b)
+}
+
+func TestTenantRouterMultipleTenants(t *testing.T) {
+	var main bytes.Buffer
+	l, r := newTenantLogger(t, &main, 2)
+
+	l.WithField("firm_id", 1).Info("one")
+	l.WithField("client_id", "abc").Info("two")
+	l.WithField("firm_id", 3).Info("three") // evicts tenant 1
+	l.WithField("firm_id", 1).Info("four")  // reopens tenant 1
+	l.Info("untenanted")
+
+	if got := readTenantFile(t, r, "1"); strings.Count(got, "\n") != 2 ||
+		!strings.Contains(got, `"msg":"one"`) || !strings.Contains(got, `"msg":"four"`) {
+		t.Errorf("tenant 1 log = %q", got)
+	}
+	if got := readTenantFile(t, r, "abc"); !strings.Contains(got, `"msg":"two"`) {
+		t.Errorf("tenant abc log = %q", got)
+	}
+	if got := readTenantFile(t, r, "3"); !strings.Contains(got, `"msg":"three"`) {
+		t.Errorf("tenant 3 log = %q", got)
+	}
+	if len(r.files) > 2 {
+		t.Errorf("%d tenant files open, want at most 2", len(r.files))
+	}
+	if got := main.String(); strings.Count(got, "\n") != 1 || !strings.Contains(got, "untenanted") {
+		t.Errorf("main output = %q", got)
+	}
+}
+
+func TestTenantRouterEscapesID(t *testing.T) {
+	var main bytes.Buffer
+	l, r := newTenantLogger(t, &main, 0)
+
+	l.WithField("firm_id", "../etc/passwd").Info("x")
+
+	if got := readTenantFile(t, r, "%2E%2E%2Fetc%2Fpasswd"); !strings.Contains(got, `"msg":"x"`) {
+		t.Errorf("escaped tenant log = %q", got)
+	}
+	if entries, _ := os.ReadDir(r.Dir); len(entries) != 1 {
+		t.Errorf("tenant dir has %d entries, want 1", len(entries))
+	}
+}
+
+func TestTenantRouterIDsDoNotCollide(t *testing.T) {
+	var main bytes.Buffer
+	l, r := newTenantLogger(t, &main, 0)
+
+	l.WithField("firm_id", "acme.corp").Info("dotted")
+	l.WithField("firm_id", "acmecorp").Info("plain")
+	l.WithField("firm_id", 1.5).Info("float")
+	l.WithField("firm_id", 15).Info("int")
+	l.WithField("firm_id", "acme%2Ecorp").Info("looks escaped")
+
+	for id, msg := range map[string]string{
+		"acme%2Ecorp":   "dotted",
+		"acmecorp":      "plain",
+		"1%2E5":         "float",
+		"15":            "int",
+		"acme%252Ecorp": "looks escaped",
+	} {
+		if got := readTenantFile(t, r, id); strings.Count(got, "\n") != 1 || !strings.Contains(got, `"msg":"`+msg+`"`) {
+			t.Errorf("tenant %s log = %q, want only %q", id, got, msg)
+		}
+	}
+}
+
+func TestTenantRouterFallsBackToMain(t *testing.T) {
+	var main bytes.Buffer
+	l, r := newTenantLogger(t, &main, 0)
+	// A file where the directory should be makes every open fail.
+	r.Dir = filepath.Join(r.Dir, "not-a-dir")
+	if err := os.WriteFile(r.Dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	l.WithField("firm_id", 1).Info("kept")
+	l.WithField("firm_id", "").Info("no tenant")
+
+	if got := main.String(); !strings.Contains(got, `"msg":"kept"`) || !strings.Contains(got, `"msg":"no tenant"`) {
+		t.Errorf("main output = %q, want both lines", got)
+	}
+}
+
+func TestTenantRouterWithAuditHook(t *testing.T) {
+	var main bytes.Buffer
+	l, r := newTenantLogger(t, &main, 0)
+
+	dir := t.TempDir()
+	audit, err := NewAuditHook(dir, "audit.log", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer audit.Close()
+	l.AddHook(audit)
+
+	l.WithFields(logrus.Fields{"firm_id": 7, "audit": true}).Info("changed plan")
+
+	if got := readTenantFile(t, r, "7"); strings.Count(got, "\n") != 1 {
+		t.Errorf("tenant log = %q, want a single line", got)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "audit.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"msg":"changed plan"`) {
+		t.Errorf("audit log = %q", b)
+	}
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SlowOpThreshold is the duration above which Timer logs at Warn instead
+// of Info. Zero disables the escalation.
+var SlowOpThreshold = time.Second
+
+// Timer starts timing the operation name and returns a func that logs its
+// duration with "op" and "duration_ms" fields, at Warn if it took longer
+// than SlowOpThreshold. Typical use:
+//
+//	defer logger.Timer(l, "load_config")()
+func Timer(l *logrus.Logger, name string) func() {
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		entry := l.WithFields(logrus.Fields{
+			"op":          name,
+			"duration_ms": elapsed.Milliseconds(),
+		})
+		if SlowOpThreshold > 0 && elapsed > SlowOpThreshold {
+			entry.Warn("slow operation")
+			return
+		}
+		entry.Info("operation finished")
+	}
+}
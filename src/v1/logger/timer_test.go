@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestTimer(t *testing.T) {
+	defer func(d time.Duration) { SlowOpThreshold = d }(SlowOpThreshold)
+
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		sleep     time.Duration
+		want      logrus.Level
+	}{
+		{"fast", time.Hour, 0, logrus.InfoLevel},
+		{"slow", 10 * time.Millisecond, 30 * time.Millisecond, logrus.WarnLevel},
+		{"escalation disabled", 0, 30 * time.Millisecond, logrus.InfoLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SlowOpThreshold = tt.threshold
+			l, hook := test.NewNullLogger()
+
+			stop := Timer(l, "load_config")
+			time.Sleep(tt.sleep)
+			stop()
+
+			e := hook.LastEntry()
+			if e == nil || e.Level != tt.want {
+				t.Fatalf("entry = %+v, want level %s", e, tt.want)
+			}
+			if e.Data["op"] != "load_config" {
+				t.Errorf("op = %v", e.Data["op"])
+			}
+			ms, ok := e.Data["duration_ms"].(int64)
+			if !ok || ms < tt.sleep.Milliseconds() || ms > tt.sleep.Milliseconds()+time.Second.Milliseconds() {
+				t.Errorf("duration_ms = %#v, want about %d", e.Data["duration_ms"], tt.sleep.Milliseconds())
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RotatingWriter is the sink a logger writes to. Implementations decide how
+// (or whether) the underlying file is rotated: Rotate is called to start a
+// new file and Close releases it.
+type RotatingWriter interface {
+	io.Writer
+	Rotate() error
+	Close() error
+}
+
+// FileWriter is the default RotatingWriter used by NewLogger: an append-only
+// file. It doesn't rotate by itself; Rotate reopens the path, so after an
+// external tool (e.g. logrotate) renames the file, writing continues in a
+// fresh one.
+type FileWriter struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewFileWriter creates logDir if needed and opens logFile inside it.
+func NewFileWriter(logDir, logFile string) (*FileWriter, error) {
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory %q: %w", logDir, err)
+	}
+	w := &FileWriter{path: filepath.Join(logDir, logFile)}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *FileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file %q: %w", w.path, err)
+	}
+	w.file = f
+	return nil
+}
+
+func (w *FileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Write(p)
+}
+
+// Rotate closes the current file and reopens the path.
+func (w *FileWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+// Sync flushes the file to disk.
+func (w *FileWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Sync()
+}
+
+// Close closes the file.
+func (w *FileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
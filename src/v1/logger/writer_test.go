@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// memRotatingWriter keeps each "file" in memory and starts a new one on
+// Rotate.
+type memRotatingWriter struct {
+	files  []strings.Builder
+	closed bool
+}
+
+func (w *memRotatingWriter) Write(p []byte) (int, error) {
+	if len(w.files) == 0 {
+		w.files = append(w.files, strings.Builder{})
+	}
+	return w.files[len(w.files)-1].Write(p)
+}
+
+func (w *memRotatingWriter) Rotate() error {
+	w.files = append(w.files, strings.Builder{})
+	return nil
+}
+
+func (w *memRotatingWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestNewLoggerWithFakeRotatingWriter(t *testing.T) {
+	w := &memRotatingWriter{}
+	l := NewLoggerWithWriter(w, "info")
+
+	l.Info("before rotation")
+	if err := w.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("after rotation")
+
+	if len(w.files) != 2 {
+		t.Fatalf("%d files, want 2", len(w.files))
+	}
+	if got := w.files[0].String(); !strings.Contains(got, `"msg":"before rotation"`) || strings.Contains(got, "after") {
+		t.Errorf("first file = %q", got)
+	}
+	if got := w.files[1].String(); !strings.Contains(got, `"msg":"after rotation"`) {
+		t.Errorf("second file = %q", got)
+	}
+}
+
+func TestFileWriterRotateAfterRename(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	w, err := NewFileWriter(dir, "app.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	path := filepath.Join(dir, "app.log")
+	w.Write([]byte("one\n"))
+	// Simulate logrotate moving the file away.
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("two\n"))
+	if err := w.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("three\n"))
+	if err := w.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	if b, _ := os.ReadFile(path + ".1"); string(b) != "one\ntwo\n" {
+		t.Errorf("rotated file = %q", b)
+	}
+	if b, _ := os.ReadFile(path); string(b) != "three\n" {
+		t.Errorf("new file = %q", b)
+	}
+}
+
+func TestNewFileWriterError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileWriter(file, "app.log"); err == nil {
+		t.Error("NewFileWriter succeeded under a regular file")
+	}
+}
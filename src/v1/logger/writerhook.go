@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"io"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WriterHook writes entries to an additional writer using its own formatter,
+// so each sink can format independently of the logger's formatter. When
+// Formatter is nil the logger's formatter is used, minus stateful wrappers
+// such as DedupFormatter.
+type WriterHook struct {
+	Writer    io.Writer
+	Formatter logrus.Formatter
+	// LogLevels limits the hook to these levels; nil means all levels.
+	LogLevels []logrus.Level
+
+	mu sync.Mutex
+}
+
+func (h *WriterHook) Levels() []logrus.Level {
+	if h.LogLevels == nil {
+		return logrus.AllLevels
+	}
+	return h.LogLevels
+}
+
+func (h *WriterHook) Fire(entry *logrus.Entry) error {
+	line, err := hookFormatter(entry, h.Formatter).Format(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err = h.Writer.Write(line)
+	return err
+}
+
+// formatterUnwrapper is implemented by stateful formatter wrappers, such as
+// DedupFormatter, that must only see the logger's own writes.
+type formatterUnwrapper interface {
+	Unwrap() logrus.Formatter
+}
+
+// hookFormatter returns the formatter a hook should use: its own if set,
+// otherwise the logger's with stateful wrappers removed, so formatting in a
+// hook doesn't feed their state or swallow the main write.
+func hookFormatter(entry *logrus.Entry, own logrus.Formatter) logrus.Formatter {
+	if own != nil {
+		return own
+	}
+	f := entry.Logger.Formatter
+	for {
+		u, ok := f.(formatterUnwrapper)
+		if !ok {
+			return f
+		}
+		f = u.Unwrap()
+	}
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestWriterHookFormatsIndependently(t *testing.T) {
+	var console, file bytes.Buffer
+	l := logrus.New()
+	l.SetOutput(&console)
+	l.SetLevel(logrus.DebugLevel)
+	l.SetFormatter(&logrus.TextFormatter{DisableColors: true, DisableTimestamp: true})
+	l.AddHook(&WriterHook{Writer: &file, Formatter: &JSONFormatter{}})
+
+	l.WithField("user", "bob").Debug("starting")
+
+	if got := console.String(); !strings.Contains(got, `level=debug msg=starting user=bob`) {
+		t.Errorf("console = %q, want text output", got)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(file.Bytes(), &entry); err != nil {
+		t.Fatalf("file = %q is not JSON: %v", file.String(), err)
+	}
+	if entry["msg"] != "starting" || entry["level"] != "DEBUG" || entry["user"] != "bob" {
+		t.Errorf("file entry = %v", entry)
+	}
+}
+
+func TestWriterHookLevels(t *testing.T) {
+	var errs bytes.Buffer
+	l := logrus.New()
+	l.SetOutput(&bytes.Buffer{})
+	l.SetFormatter(&JSONFormatter{})
+	l.AddHook(&WriterHook{Writer: &errs, LogLevels: []logrus.Level{logrus.ErrorLevel}})
+
+	l.Info("routine")
+	l.Error("broken")
+
+	got := errs.String()
+	if strings.Contains(got, "routine") || !strings.Contains(got, `"msg":"broken"`) {
+		t.Errorf("hook output = %q, want only the error, in the logger's format", got)
+	}
+}
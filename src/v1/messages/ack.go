@@ -0,0 +1,20 @@
+package messages
+
+// WithAck wraps a handler so the broker is acknowledged based on its result:
+// ack on success, nack on error. The nack requeues the message unless its
+// retries are exhausted, i.e. Head.Attempt (1-based, unset counts as the
+// first attempt) has reached maxAttempts. The handler's error is returned.
+func WithAck[T any](maxAttempts int, handler func(Message[T]) error, ack func(), nack func(requeue bool)) func(Message[T]) error {
+	return func(m Message[T]) error {
+		if err := handler(m); err != nil {
+			attempt := m.Head.Attempt
+			if attempt < 1 {
+				attempt = 1
+			}
+			nack(attempt < maxAttempts)
+			return err
+		}
+		ack()
+		return nil
+	}
+}
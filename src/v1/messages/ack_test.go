@@ -0,0 +1,52 @@
+package messages
+
+import (
+	"errors"
+	"testing"
+)
+
+type ackRecorder struct {
+	acks    int
+	nacks   int
+	requeue bool
+}
+
+func (r *ackRecorder) ack() { r.acks++ }
+func (r *ackRecorder) nack(requeue bool) {
+	r.nacks++
+	r.requeue = requeue
+}
+
+func TestWithAck(t *testing.T) {
+	boom := errors.New("boom")
+	tests := []struct {
+		name      string
+		attempt   int
+		err       error
+		acks      int
+		nacks     int
+		requeue   bool
+		wantError bool
+	}{
+		{"success", 1, nil, 1, 0, false, false},
+		{"retryable error", 1, boom, 0, 1, true, true},
+		{"unset attempt counts as first", 0, boom, 0, 1, true, true},
+		{"last retry", 2, boom, 0, 1, true, true},
+		{"retries exhausted", 3, boom, 0, 1, false, true},
+		{"beyond the limit", 7, boom, 0, 1, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r ackRecorder
+			h := WithAck(3, func(Message[string]) error { return tt.err }, r.ack, r.nack)
+
+			err := h(Message[string]{Head: Head{Attempt: tt.attempt}})
+			if (err != nil) != tt.wantError || (tt.err != nil && !errors.Is(err, tt.err)) {
+				t.Errorf("err = %v", err)
+			}
+			if r.acks != tt.acks || r.nacks != tt.nacks || r.requeue != tt.requeue {
+				t.Errorf("acks = %d, nacks = %d, requeue = %v", r.acks, r.nacks, r.requeue)
+			}
+		})
+	}
+}
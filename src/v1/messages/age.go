@@ -0,0 +1,50 @@
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Timestamp returns Head.Time, which holds unix seconds, as a time.Time.
+func (h Head) Timestamp() time.Time {
+	return time.Unix(int64(h.Time), 0)
+}
+
+// Age returns how long ago the message was stamped, relative to now.
+// Timestamps in the future (clock skew between services) yield zero rather
+// than a negative age.
+func (h Head) Age(now time.Time) time.Duration {
+	age := now.Sub(h.Timestamp())
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+var (
+	// ErrMissingTime is returned by ValidateFreshness when Head.Time is unset.
+	ErrMissingTime = errors.New("message has no time")
+	// ErrMessageTooOld is returned when a message is older than the allowed age.
+	ErrMessageTooOld = errors.New("message is too old")
+	// ErrMessageFromFuture is returned when a message is dated beyond the
+	// allowed clock skew.
+	ErrMessageFromFuture = errors.New("message is dated in the future")
+)
+
+// ValidateFreshness rejects messages without a time, dated more than maxSkew
+// after now, or older than maxAge. A zero maxSkew or maxAge disables that
+// check.
+func (h Head) ValidateFreshness(now time.Time, maxSkew, maxAge time.Duration) error {
+	if h.Time == 0 {
+		return ErrMissingTime
+	}
+	ts := h.Timestamp()
+	if maxSkew > 0 && ts.Sub(now) > maxSkew {
+		return fmt.Errorf("%w: %s ahead", ErrMessageFromFuture, ts.Sub(now))
+	}
+	if maxAge > 0 && now.Sub(ts) > maxAge {
+		return fmt.Errorf("%w: %s old", ErrMessageTooOld, now.Sub(ts))
+	}
+	return nil
+}
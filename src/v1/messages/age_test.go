@@ -0,0 +1,70 @@
+package messages
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var ageNow = time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)
+
+func stampedAt(t time.Time) Head {
+	return Head{Time: int(t.Unix())}
+}
+
+func TestHeadAge(t *testing.T) {
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Duration
+	}{
+		{"past", ageNow.Add(-90 * time.Second), 90 * time.Second},
+		{"now", ageNow, 0},
+		{"future is clamped", ageNow.Add(time.Hour), 0},
+	}
+	for _, tt := range tests {
+		if got := stampedAt(tt.at).Age(ageNow); got != tt.want {
+			t.Errorf("%s: Age = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if ts := stampedAt(ageNow).Timestamp(); !ts.Equal(ageNow) {
+		t.Errorf("Timestamp = %v", ts)
+	}
+}
+
+func TestValidateFreshness(t *testing.T) {
+	const skew, maxAge = 5 * time.Second, time.Minute
+	tests := []struct {
+		name string
+		head Head
+		want error
+	}{
+		{"fresh", stampedAt(ageNow.Add(-10 * time.Second)), nil},
+		{"within skew", stampedAt(ageNow.Add(5 * time.Second)), nil},
+		{"at max age", stampedAt(ageNow.Add(-time.Minute)), nil},
+		{"too old", stampedAt(ageNow.Add(-61 * time.Second)), ErrMessageTooOld},
+		{"future dated", stampedAt(ageNow.Add(6 * time.Second)), ErrMessageFromFuture},
+		{"no time", Head{}, ErrMissingTime},
+	}
+	for _, tt := range tests {
+		err := tt.head.ValidateFreshness(ageNow, skew, maxAge)
+		if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("%s: ValidateFreshness = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestValidateFreshnessDisabledChecks(t *testing.T) {
+	future := stampedAt(ageNow.Add(time.Hour))
+	old := stampedAt(ageNow.Add(-24 * time.Hour))
+	if err := future.ValidateFreshness(ageNow, 0, time.Minute); err != nil {
+		t.Errorf("zero maxSkew: %v", err)
+	}
+	if err := old.ValidateFreshness(ageNow, time.Second, 0); err != nil {
+		t.Errorf("zero maxAge: %v", err)
+	}
+	if err := (Head{}).ValidateFreshness(ageNow, 0, 0); !errors.Is(err, ErrMissingTime) {
+		t.Errorf("missing time with checks off = %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCorrelationTimeout is returned when replies for a correlation ID
+	// don't all arrive in time, including when the ID was already evicted.
+	ErrCorrelationTimeout = errors.New("correlation timed out")
+	// ErrUnknownCorrelation is returned by Wait for an ID never registered
+	// with Expect.
+	ErrUnknownCorrelation = errors.New("correlation id is not expected")
+)
+
+// AggregateResult holds the replies collected for one correlation ID.
+type AggregateResult[T any] struct {
+	// Bodies are the received bodies ordered by Head.Sequence.
+	Bodies []T
+	// Missing lists the sequence numbers that never arrived.
+	Missing []int
+}
+
+// Aggregator collects the replies of scatter-gather requests. Each of the N
+// sub-requests sharing a correlation ID is expected to be answered with a
+// reply whose Head.Sequence is its index in [0, N).
+type Aggregator[T any] struct {
+	mu      sync.Mutex
+	pending map[string]*aggregation[T]
+	ttl     time.Duration
+	// swept remembers recently evicted IDs so a late Wait reports a
+	// timeout; entries are dropped after another TTL.
+	swept map[string]time.Time
+
+	stop      chan struct{}
+	stopOnce  sync.Once
+	sweeperWG sync.WaitGroup
+}
+
+type aggregation[T any] struct {
+	expected int
+	quorum   int
+	received map[int]T
+	created  time.Time
+	done     chan struct{}
+	closed   bool
+	expired  bool
+}
+
+func (agg *aggregation[T]) finish() {
+	if !agg.closed {
+		agg.closed = true
+		close(agg.done)
+	}
+}
+
+// NewAggregator creates an empty aggregator. Abandoned correlation IDs are
+// kept until waited on; use NewAggregatorWithTTL to evict them.
+func NewAggregator[T any]() *Aggregator[T] {
+	return &Aggregator[T]{
+		pending: make(map[string]*aggregation[T]),
+		swept:   make(map[string]time.Time),
+		stop:    make(chan struct{}),
+	}
+}
+
+// NewAggregatorWithTTL creates an aggregator whose background sweeper
+// evicts correlation IDs older than ttl, failing their waiters with
+// ErrCorrelationTimeout. Call Close to stop the sweeper.
+func NewAggregatorWithTTL[T any](ttl time.Duration) *Aggregator[T] {
+	a := NewAggregator[T]()
+	a.ttl = ttl
+
+	interval := ttl / 2
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	a.sweeperWG.Add(1)
+	go func() {
+		defer a.sweeperWG.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.Sweep()
+			case <-a.stop:
+				return
+			}
+		}
+	}()
+	return a
+}
+
+// Sweep evicts correlation IDs registered longer than the TTL ago. It is a
+// no-op for aggregators created without a TTL.
+func (a *Aggregator[T]) Sweep() {
+	if a.ttl <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	t := now()
+	cutoff := t.Add(-a.ttl)
+	for id, sweptAt := range a.swept {
+		if sweptAt.Before(cutoff) {
+			delete(a.swept, id)
+		}
+	}
+	for id, agg := range a.pending {
+		if agg.created.Before(cutoff) {
+			delete(a.pending, id)
+			a.swept[id] = t
+			agg.expired = true
+			agg.finish()
+		}
+	}
+}
+
+// Close stops the background sweeper, if any.
+func (a *Aggregator[T]) Close() {
+	a.stopOnce.Do(func() { close(a.stop) })
+	a.sweeperWG.Wait()
+}
+
+// Expect registers a correlation ID awaiting n replies.
+func (a *Aggregator[T]) Expect(correlationID string, n int) {
+	a.ExpectQuorum(correlationID, n, n)
+}
+
+// ExpectQuorum registers a correlation ID awaiting n replies, of which
+// quorum are enough: Wait returns successfully as soon as quorum replies
+// have arrived, listing the others as Missing. A quorum outside [1, n]
+// means all n.
+func (a *Aggregator[T]) ExpectQuorum(correlationID string, n, quorum int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if quorum <= 0 || quorum > n {
+		quorum = n
+	}
+	delete(a.swept, correlationID)
+	agg := &aggregation[T]{
+		expected: n,
+		quorum:   quorum,
+		received: make(map[int]T, n),
+		created:  now(),
+		done:     make(chan struct{}),
+	}
+	if n <= 0 {
+		agg.finish()
+	}
+	a.pending[correlationID] = agg
+}
+
+// Collect records a reply. It returns false if the correlation ID isn't
+// expected, or the sequence is out of range or already received.
+func (a *Aggregator[T]) Collect(msg Message[T]) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	agg, ok := a.pending[msg.Head.Correlationid]
+	if !ok {
+		return false
+	}
+	seq := msg.Head.Sequence
+	if seq < 0 || seq >= agg.expected {
+		return false
+	}
+	if _, dup := agg.received[seq]; dup {
+		return false
+	}
+	agg.received[seq] = msg.Body
+	if len(agg.received) >= agg.quorum {
+		agg.finish()
+	}
+	return true
+}
+
+// Wait blocks until all replies for correlationID (or its quorum, see
+// ExpectQuorum) arrived or ctx is done, then forgets the correlation ID. On
+// timeout it returns the partial result with ErrCorrelationTimeout.
+func (a *Aggregator[T]) Wait(ctx context.Context, correlationID string) (AggregateResult[T], error) {
+	a.mu.Lock()
+	agg, ok := a.pending[correlationID]
+	if !ok {
+		_, wasSwept := a.swept[correlationID]
+		delete(a.swept, correlationID)
+		a.mu.Unlock()
+		if wasSwept {
+			return AggregateResult[T]{}, ErrCorrelationTimeout
+		}
+		return AggregateResult[T]{}, ErrUnknownCorrelation
+	}
+	a.mu.Unlock()
+
+	var err error
+	select {
+	case <-agg.done:
+	case <-ctx.Done():
+		err = ErrCorrelationTimeout
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pending[correlationID] == agg {
+		delete(a.pending, correlationID)
+	}
+	if agg.expired {
+		err = ErrCorrelationTimeout
+	}
+
+	var res AggregateResult[T]
+	for seq := 0; seq < agg.expected; seq++ {
+		if body, ok := agg.received[seq]; ok {
+			res.Bodies = append(res.Bodies, body)
+		} else {
+			res.Missing = append(res.Missing, seq)
+		}
+	}
+	if len(agg.received) >= agg.quorum {
+		err = nil
+	}
+	return res, err
+}
@@ -0,0 +1,183 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func reply(correlationID string, seq int, body string) Message[string] {
+	return Message[string]{Head: Head{Correlationid: correlationID, Sequence: seq}, Body: body}
+}
+
+func TestAggregatorCollectsAll(t *testing.T) {
+	a := NewAggregator[string]()
+	a.Expect("c1", 3)
+
+	go func() {
+		for _, seq := range []int{2, 0, 1} {
+			a.Collect(reply("c1", seq, string(rune('a'+seq))))
+		}
+	}()
+
+	res, err := a.Wait(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(res.Bodies, want) || len(res.Missing) != 0 {
+		t.Errorf("result = %+v, want bodies %v in sequence order", res, want)
+	}
+}
+
+func TestAggregatorRejectsBadReplies(t *testing.T) {
+	a := NewAggregator[string]()
+	a.Expect("c1", 2)
+
+	if a.Collect(reply("other", 0, "x")) {
+		t.Error("accepted a reply for an unknown correlation ID")
+	}
+	if a.Collect(reply("c1", 2, "x")) || a.Collect(reply("c1", -1, "x")) {
+		t.Error("accepted an out-of-range sequence")
+	}
+	if !a.Collect(reply("c1", 0, "x")) || a.Collect(reply("c1", 0, "y")) {
+		t.Error("duplicate handling is wrong")
+	}
+}
+
+func TestAggregatorTimeoutReportsMissing(t *testing.T) {
+	a := NewAggregator[string]()
+	a.Expect("c1", 3)
+	a.Collect(reply("c1", 1, "b"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	res, err := a.Wait(ctx, "c1")
+
+	if !errors.Is(err, ErrCorrelationTimeout) {
+		t.Errorf("err = %v, want ErrCorrelationTimeout", err)
+	}
+	if !reflect.DeepEqual(res.Bodies, []string{"b"}) || !reflect.DeepEqual(res.Missing, []int{0, 2}) {
+		t.Errorf("result = %+v", res)
+	}
+	if a.Collect(reply("c1", 0, "late")) {
+		t.Error("correlation ID was not forgotten after Wait")
+	}
+}
+
+func TestAggregatorQuorumReleasesEarly(t *testing.T) {
+	a := NewAggregator[string]()
+	a.ExpectQuorum("c1", 5, 2)
+	a.Collect(reply("c1", 3, "d"))
+	a.Collect(reply("c1", 0, "a"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	res, err := a.Wait(ctx, "c1")
+
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Wait did not return once the quorum was reached")
+	}
+	if !reflect.DeepEqual(res.Bodies, []string{"a", "d"}) || !reflect.DeepEqual(res.Missing, []int{1, 2, 4}) {
+		t.Errorf("result = %+v", res)
+	}
+}
+
+func TestAggregatorQuorumNotReached(t *testing.T) {
+	a := NewAggregator[string]()
+	a.ExpectQuorum("c1", 3, 2)
+	a.Collect(reply("c1", 0, "a"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := a.Wait(ctx, "c1"); !errors.Is(err, ErrCorrelationTimeout) {
+		t.Errorf("err = %v, want ErrCorrelationTimeout", err)
+	}
+}
+
+func TestAggregatorSweepEvictsAbandoned(t *testing.T) {
+	clk := useFakeClock(t)
+	a := NewAggregator[string]()
+	a.ttl = time.Minute // as NewAggregatorWithTTL, without the background sweeper
+
+	a.Expect("abandoned", 2)
+	waited := make(chan error, 1)
+	go func() {
+		_, err := a.Wait(context.Background(), "abandoned")
+		waited <- err
+	}()
+
+	clk.Advance(30 * time.Second)
+	a.Expect("fresh", 1)
+	clk.Advance(31 * time.Second)
+	a.Sweep()
+
+	select {
+	case err := <-waited:
+		if !errors.Is(err, ErrCorrelationTimeout) {
+			t.Errorf("waiter got %v, want ErrCorrelationTimeout", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not released by the sweep")
+	}
+	if _, ok := a.pending["fresh"]; !ok {
+		t.Error("sweep evicted an ID younger than the TTL")
+	}
+}
+
+func TestAggregatorWaitAfterSweep(t *testing.T) {
+	clk := useFakeClock(t)
+	a := NewAggregator[string]()
+	a.ttl = time.Minute
+
+	a.Expect("c1", 1)
+	clk.Advance(2 * time.Minute)
+	a.Sweep()
+
+	if _, err := a.Wait(context.Background(), "c1"); !errors.Is(err, ErrCorrelationTimeout) {
+		t.Errorf("Wait on a swept ID = %v, want ErrCorrelationTimeout", err)
+	}
+	if _, err := a.Wait(context.Background(), "never"); !errors.Is(err, ErrUnknownCorrelation) {
+		t.Errorf("Wait on an unknown ID = %v, want ErrUnknownCorrelation", err)
+	}
+
+	a.Expect("c2", 1)
+	clk.Advance(2 * time.Minute)
+	a.Sweep()
+	clk.Advance(2 * time.Minute)
+	a.Sweep()
+	if len(a.swept) != 0 {
+		t.Errorf("swept IDs kept forever: %v", a.swept)
+	}
+}
+
+func TestAggregatorWithTTLSweeperStops(t *testing.T) {
+	a := NewAggregatorWithTTL[string](10 * time.Millisecond)
+	a.Expect("abandoned", 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := a.Wait(ctx, "abandoned"); !errors.Is(err, ErrCorrelationTimeout) {
+		t.Errorf("err = %v, want ErrCorrelationTimeout", err)
+	}
+	if ctx.Err() != nil {
+		t.Error("background sweeper did not evict before the context deadline")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		a.Close()
+		a.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not stop the sweeper")
+	}
+}
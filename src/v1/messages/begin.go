@@ -0,0 +1,27 @@
+package messages
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Begin prepares a handler for m: it stores the message's correlation ID
+// (generating one if missing) and source in ctx, and returns an entry
+// tagged with correlation_id, event_type and source.
+//
+//	ctx, log := messages.Begin(ctx, l, msg)
+func Begin[T any](ctx context.Context, l *logrus.Logger, m Message[T]) (context.Context, *logrus.Entry) {
+	correlationID := m.Head.Correlationid
+	if correlationID == "" {
+		correlationID = NewCorrelationID()
+	}
+	ctx = WithCorrelationID(ctx, correlationID)
+	ctx = WithSource(ctx, m.Head.Source)
+
+	return ctx, l.WithContext(ctx).WithFields(logrus.Fields{
+		"correlation_id": correlationID,
+		"event_type":     m.Head.Eventtype,
+		"source":         m.Head.Source,
+	})
+}
@@ -0,0 +1,58 @@
+package messages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestBegin(t *testing.T) {
+	l, hook := test.NewNullLogger()
+	m := Message[string]{Head: Head{Correlationid: "c-1", Eventtype: "order.placed", Source: "api"}}
+
+	ctx, log := Begin(context.Background(), l, m)
+
+	if id, ok := CorrelationIDFromContext(ctx); !ok || id != "c-1" {
+		t.Errorf("context correlation ID = %q, %v", id, ok)
+	}
+	if src, ok := SourceFromContext(ctx); !ok || src != "api" {
+		t.Errorf("context source = %q, %v", src, ok)
+	}
+
+	log.Info("handling")
+	e := hook.LastEntry()
+	if e.Data["correlation_id"] != "c-1" || e.Data["event_type"] != "order.placed" || e.Data["source"] != "api" {
+		t.Errorf("logged fields = %v", e.Data)
+	}
+	if e.Context != ctx {
+		t.Error("entry does not carry the handler context")
+	}
+}
+
+func TestBeginGeneratesCorrelationID(t *testing.T) {
+	l, hook := test.NewNullLogger()
+
+	ctx, log := Begin(context.Background(), l, Message[string]{Head: Head{Eventtype: "e"}})
+	id, ok := CorrelationIDFromContext(ctx)
+	if !ok || len(id) != 36 {
+		t.Fatalf("generated correlation ID = %q, %v", id, ok)
+	}
+	if _, ok := SourceFromContext(ctx); ok {
+		t.Error("empty source reported as present")
+	}
+
+	log.Info("handling")
+	if got := hook.LastEntry().Data["correlation_id"]; got != id {
+		t.Errorf("logged correlation_id = %v, want the context's %q", got, id)
+	}
+}
+
+func TestSourceFromContext(t *testing.T) {
+	if _, ok := SourceFromContext(context.Background()); ok {
+		t.Error("empty context reported a source")
+	}
+	if src, ok := SourceFromContext(WithSource(context.Background(), "worker")); !ok || src != "worker" {
+		t.Errorf("SourceFromContext = %q, %v", src, ok)
+	}
+}
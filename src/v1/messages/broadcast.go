@@ -0,0 +1,60 @@
+package messages
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// EmitAllError reports the destinations EmitAll failed to emit to.
+type EmitAllError struct {
+	Failed map[string]error
+}
+
+func (e *EmitAllError) Error() string {
+	dests := make([]string, 0, len(e.Failed))
+	for d := range e.Failed {
+		dests = append(dests, d)
+	}
+	sort.Strings(dests)
+
+	parts := make([]string, len(dests))
+	for i, d := range dests {
+		parts[i] = fmt.Sprintf("%s: %v", d, e.Failed[d])
+	}
+	return fmt.Sprintf("failed to emit to %d destinations: %s", len(dests), strings.Join(parts, "; "))
+}
+
+func (e *EmitAllError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// EmitAll emits a copy of base to every destination, each with its own
+// correlation ID and its own copy of the head's baggage and trace, so an
+// emit that changes one copy can't affect the others. All destinations are
+// attempted; failures are reported in an *EmitAllError. This is best
+// effort: whether the broadcast is atomic depends on the broker behind
+// emit, as already emitted copies aren't rolled back.
+func EmitAll(emit func(SendingMessage) error, base SendingMessage, destinations []string) error {
+	failed := make(map[string]error)
+	for _, dest := range destinations {
+		m := base
+		m.Head.Destination = dest
+		m.Head.Baggage = maps.Clone(base.Head.Baggage)
+		m.Head.Trace = slices.Clone(base.Head.Trace)
+		m.Head.Correlationid = NewCorrelationID()
+		if err := emit(m); err != nil {
+			failed[dest] = err
+		}
+	}
+	if len(failed) > 0 {
+		return &EmitAllError{Failed: failed}
+	}
+	return nil
+}
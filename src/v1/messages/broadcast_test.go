@@ -0,0 +1,56 @@
+package messages
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEmitAll(t *testing.T) {
+	var base SendingMessage
+	base.Head.Eventtype = "order.created"
+	base.Head.SetBaggage("tenant", "acme")
+	base.AddHop("api")
+
+	emitted := make(map[string]SendingMessage)
+	err := EmitAll(func(m SendingMessage) error {
+		// Mutate the copy the way a downstream emit might.
+		m.Head.SetBaggage("dest", m.Head.Destination)
+		m.Head.Trace[0].Source = "rewritten-by-" + m.Head.Destination
+		m.AddHop(m.Head.Destination)
+		emitted[m.Head.Destination] = m
+		if m.Head.Destination == "b" {
+			return errors.New("broker down")
+		}
+		return nil
+	}, base, []string{"a", "b", "c"})
+
+	var emitErr *EmitAllError
+	if !errors.As(err, &emitErr) {
+		t.Fatalf("err = %v, want *EmitAllError", err)
+	}
+	if len(emitErr.Failed) != 1 || emitErr.Failed["b"] == nil {
+		t.Errorf("Failed = %v, want only b", emitErr.Failed)
+	}
+
+	if len(base.Head.Baggage) != 1 || base.Head.Baggage["tenant"] != "acme" {
+		t.Errorf("base baggage changed: %v", base.Head.Baggage)
+	}
+	if len(base.Head.Trace) != 1 || base.Head.Trace[0].Source != "api" {
+		t.Errorf("base trace changed: %+v", base.Head.Trace)
+	}
+
+	ids := make(map[string]bool)
+	for _, dest := range []string{"a", "b", "c"} {
+		m := emitted[dest]
+		if m.Head.Baggage["dest"] != dest || m.Head.Baggage["tenant"] != "acme" {
+			t.Errorf("%s baggage = %v", dest, m.Head.Baggage)
+		}
+		if path := m.Path(); len(path) != 2 || path[1] != dest {
+			t.Errorf("%s path = %v", dest, path)
+		}
+		ids[m.Head.Correlationid] = true
+	}
+	if len(ids) != 3 {
+		t.Errorf("correlation IDs not unique: %v", ids)
+	}
+}
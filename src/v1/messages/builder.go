@@ -0,0 +1,59 @@
+package messages
+
+// Builder assembles a Message with a fluent API.
+type Builder[T any] struct {
+	head Head
+	body T
+}
+
+// Build starts a new message builder for body type T.
+func Build[T any]() *Builder[T] {
+	return &Builder[T]{}
+}
+
+func (b *Builder[T]) Source(s string) *Builder[T] {
+	b.head.Source = s
+	return b
+}
+
+func (b *Builder[T]) Destination(d string) *Builder[T] {
+	b.head.Destination = d
+	return b
+}
+
+func (b *Builder[T]) EventType(e string) *Builder[T] {
+	b.head.Eventtype = e
+	return b
+}
+
+func (b *Builder[T]) Correlation(c string) *Builder[T] {
+	b.head.Correlationid = c
+	return b
+}
+
+// Time sets the head time as unix seconds.
+func (b *Builder[T]) Time(t int) *Builder[T] {
+	b.head.Time = t
+	return b
+}
+
+func (b *Builder[T]) Body(body T) *Builder[T] {
+	b.body = body
+	return b
+}
+
+// Message validates the head and returns the message. Time defaults to now
+// and the correlation ID to a newly generated one when unset.
+func (b *Builder[T]) Message() (Message[T], error) {
+	head := b.head
+	if err := head.Validate(); err != nil {
+		return Message[T]{}, err
+	}
+	if head.Time == 0 {
+		head.Time = int(now().Unix())
+	}
+	if head.Correlationid == "" {
+		head.Correlationid = NewCorrelationID()
+	}
+	return Message[T]{Head: head, Body: b.body}, nil
+}
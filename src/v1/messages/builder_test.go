@@ -0,0 +1,79 @@
+package messages
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuilderDefaults(t *testing.T) {
+	before := time.Now().Unix()
+	m, err := Build[string]().
+		Source("api").
+		Destination("billing").
+		EventType("invoice.paid").
+		Body("payload").
+		Message()
+	after := time.Now().Unix()
+	if err != nil {
+		t.Fatalf("Message: %v", err)
+	}
+
+	if m.Head.Source != "api" || m.Head.Destination != "billing" || m.Head.Eventtype != "invoice.paid" {
+		t.Errorf("head = %+v", m.Head)
+	}
+	if m.Body != "payload" {
+		t.Errorf("body = %q", m.Body)
+	}
+	if got := int64(m.Head.Time); got < before || got > after {
+		t.Errorf("time = %d, want between %d and %d", got, before, after)
+	}
+	if m.Head.Correlationid == "" {
+		t.Error("correlation ID not generated")
+	}
+
+	other, _ := Build[string]().Source("a").Destination("b").EventType("c").Message()
+	if other.Head.Correlationid == m.Head.Correlationid {
+		t.Error("generated correlation IDs are not unique")
+	}
+}
+
+func TestBuilderKeepsExplicitValues(t *testing.T) {
+	m, err := Build[int]().
+		Source("api").
+		Destination("billing").
+		EventType("invoice.paid").
+		Correlation("c-1").
+		Time(1700000000).
+		Body(7).
+		Message()
+	if err != nil {
+		t.Fatalf("Message: %v", err)
+	}
+	if m.Head.Correlationid != "c-1" || m.Head.Time != 1700000000 || m.Body != 7 {
+		t.Errorf("message = %+v", m)
+	}
+}
+
+func TestBuilderValidates(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *Builder[string]
+	}{
+		{"no source", Build[string]().Destination("d").EventType("e")},
+		{"no destination", Build[string]().Source("s").EventType("e")},
+		{"no event type", Build[string]().Source("s").Destination("d")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := tt.b.Message()
+			if !errors.Is(err, ErrInvalidMessage) {
+				t.Errorf("err = %v, want ErrInvalidMessage", err)
+			}
+			if !reflect.DeepEqual(m, Message[string]{}) {
+				t.Errorf("invalid build returned %+v", m)
+			}
+		})
+	}
+}
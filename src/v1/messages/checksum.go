@@ -0,0 +1,49 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// ErrChecksumMismatch is returned by Decode when the body doesn't match the
+// head's checksum.
+var ErrChecksumMismatch = errors.New("message checksum mismatch")
+
+// bodyChecksum returns the hex CRC32 (IEEE) of the encoded body.
+func bodyChecksum(body []byte) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE(body))
+}
+
+// Encode marshals m with Head.Checksum set to the CRC32 of the encoded body,
+// so truncated or corrupted payloads can be detected by Decode.
+func Encode[T any](m Message[T]) ([]byte, error) {
+	body, err := json.Marshal(m.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message body: %w", err)
+	}
+	head := m.Head
+	head.Checksum = bodyChecksum(body)
+	return WrapRaw(head, body), nil
+}
+
+// Decode unmarshals data into a Message[T]. When verify is true and the head
+// carries a checksum, the body bytes are checked against it before decoding
+// and ErrChecksumMismatch is returned on mismatch. Messages without a
+// checksum are accepted for backward compatibility.
+func Decode[T any](data []byte, verify bool) (Message[T], error) {
+	head, raw, err := UnwrapRaw(data)
+	if err != nil {
+		return Message[T]{}, err
+	}
+	if verify && head.Checksum != "" && head.Checksum != bodyChecksum(raw) {
+		return Message[T]{}, ErrChecksumMismatch
+	}
+
+	m := Message[T]{Head: head}
+	if err := json.Unmarshal(raw, &m.Body); err != nil {
+		return Message[T]{}, fmt.Errorf("failed to unmarshal message body: %w", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,60 @@
+package messages
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type checksumBody struct {
+	Amount int    `json:"amount"`
+	Note   string `json:"note"`
+}
+
+func TestChecksumMatching(t *testing.T) {
+	in := Message[checksumBody]{Head: Head{Eventtype: "e"}, Body: checksumBody{Amount: 100, Note: "ok"}}
+	data, err := Encode(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"checksum":"`)) {
+		t.Fatalf("no checksum in %s", data)
+	}
+
+	out, err := Decode[checksumBody](data, true)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if out.Body != in.Body || out.Head.Eventtype != "e" {
+		t.Errorf("decoded %+v", out)
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	data, _ := Encode(Message[checksumBody]{Head: Head{Eventtype: "e"}, Body: checksumBody{Amount: 100}})
+	corrupted := bytes.Replace(data, []byte(`"amount":100`), []byte(`"amount":900`), 1)
+
+	if _, err := Decode[checksumBody](corrupted, true); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Decode = %v, want ErrChecksumMismatch", err)
+	}
+	// Verification is optional.
+	if out, err := Decode[checksumBody](corrupted, false); err != nil || out.Body.Amount != 900 {
+		t.Errorf("unverified Decode = %+v, %v", out, err)
+	}
+}
+
+func TestChecksumAbsent(t *testing.T) {
+	legacy := []byte(`{"head":{"event_type":"e"},"body":{"amount":5}}`)
+	out, err := Decode[checksumBody](legacy, true)
+	if err != nil || out.Body.Amount != 5 {
+		t.Errorf("Decode without checksum = %+v, %v", out, err)
+	}
+}
+
+func TestChecksumTruncated(t *testing.T) {
+	data, _ := Encode(Message[checksumBody]{Head: Head{Eventtype: "e"}, Body: checksumBody{Note: strings.Repeat("x", 100)}})
+	if _, err := Decode[checksumBody](data[:len(data)-20], true); err == nil {
+		t.Error("Decode accepted a truncated message")
+	}
+}
@@ -0,0 +1,47 @@
+package messages
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock supplies the current time to time-dependent helpers.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to Clock, e.g. to inject a fixed time in tests.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+type clockHolder struct {
+	Clock
+}
+
+var clock atomic.Value
+
+func init() {
+	clock.Store(clockHolder{systemClock{}})
+}
+
+// SetClock replaces the package clock; nil restores the system clock.
+func SetClock(c Clock) {
+	if c == nil {
+		c = systemClock{}
+	}
+	clock.Store(clockHolder{c})
+}
+
+// now returns the current time from the package clock.
+func now() time.Time {
+	return clock.Load().(clockHolder).Now()
+}
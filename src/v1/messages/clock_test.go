@@ -0,0 +1,75 @@
+package messages
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock tests can move forward.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// useFakeClock installs a fake clock at a fixed time for the test.
+func useFakeClock(t *testing.T) *fakeClock {
+	t.Helper()
+	c := &fakeClock{t: time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)}
+	SetClock(c)
+	t.Cleanup(func() { SetClock(nil) })
+	return c
+}
+
+func TestSetClock(t *testing.T) {
+	c := useFakeClock(t)
+	if got := now(); !got.Equal(c.Now()) {
+		t.Errorf("now = %v, want the fake time %v", got, c.Now())
+	}
+	c.Advance(90 * time.Second)
+	if got := now(); !got.Equal(time.Date(2025, 1, 22, 12, 1, 30, 0, time.UTC)) {
+		t.Errorf("now after Advance = %v", got)
+	}
+
+	SetClock(ClockFunc(func() time.Time { return time.Unix(42, 0) }))
+	if got := now().Unix(); got != 42 {
+		t.Errorf("ClockFunc now = %d", got)
+	}
+
+	SetClock(nil)
+	if d := time.Since(now()); d < 0 || d > time.Minute {
+		t.Errorf("SetClock(nil) did not restore the system clock: now is %v off", d)
+	}
+}
+
+func TestClockDrivesTimestamps(t *testing.T) {
+	c := useFakeClock(t)
+	start := c.Now().Unix()
+
+	m, err := Build[string]().Source("s").Destination("d").EventType("e").Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(m.Head.Time) != start {
+		t.Errorf("built time = %d, want %d", m.Head.Time, start)
+	}
+
+	m.AddHop("a")
+	c.Advance(5 * time.Second)
+	m.AddHop("b")
+	if m.Head.Trace[0].Time != int(start) || m.Head.Trace[1].Time != int(start+5) {
+		t.Errorf("hop times = %+v", m.Head.Trace)
+	}
+}
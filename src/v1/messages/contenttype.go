@@ -0,0 +1,66 @@
+package messages
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Content types reported by DetectContentType.
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeGzip     = "application/gzip"
+	ContentTypeText     = "text/plain"
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeUnknown  = "application/octet-stream"
+)
+
+// DetectContentType guesses the payload encoding from its leading bytes.
+// It is a heuristic: JSON is recognized by a leading '{' or '[', gzip by its
+// magic number, printable UTF-8 as text, and anything starting with a valid
+// protobuf field tag as protobuf. Other input is reported as unknown.
+func DetectContentType(data []byte) string {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	switch {
+	case len(trimmed) == 0:
+		return ContentTypeUnknown
+	case trimmed[0] == '{' || trimmed[0] == '[':
+		return ContentTypeJSON
+	case len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b:
+		return ContentTypeGzip
+	case isPrintableText(data):
+		return ContentTypeText
+	case isProtobufTag(data[0]):
+		return ContentTypeProtobuf
+	}
+	return ContentTypeUnknown
+}
+
+// isPrintableText reports whether the leading bytes are printable UTF-8.
+func isPrintableText(data []byte) bool {
+	if len(data) > 512 {
+		data = data[:512]
+	}
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size <= 1 {
+			// A rune cut off by the sniff window is still text.
+			return len(data) < utf8.UTFMax && !utf8.FullRune(data)
+		}
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+		data = data[size:]
+	}
+	return true
+}
+
+// isProtobufTag reports whether b is a plausible first protobuf key byte:
+// a non-zero field number with a known wire type.
+func isProtobufTag(b byte) bool {
+	switch b & 0x07 {
+	case 0, 1, 2, 5:
+		return b>>3 != 0
+	}
+	return false
+}
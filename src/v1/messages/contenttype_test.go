@@ -0,0 +1,44 @@
+package messages
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+)
+
+func TestDetectContentType(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte(`{"head":{}}`))
+	zw.Close()
+
+	// A rune cut by the 512-byte sniff window.
+	cutRune := []byte(strings.Repeat("a", 511) + "é")
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"json object", []byte(`{"head":{}}`), ContentTypeJSON},
+		{"json array", []byte(`[1,2]`), ContentTypeJSON},
+		{"json with leading whitespace", []byte(" \n\t{}"), ContentTypeJSON},
+		{"gzip", gz.Bytes(), ContentTypeGzip},
+		{"text", []byte("hello, wörld\n"), ContentTypeText},
+		{"text cut in a rune", cutRune, ContentTypeText},
+		{"protobuf varint field 1", []byte{0x08, 0x96, 0x01}, ContentTypeProtobuf},
+		{"protobuf bytes field 2", []byte{0x12, 0x03, 0xff, 0xfe, 0x00}, ContentTypeProtobuf},
+		{"empty", nil, ContentTypeUnknown},
+		{"only whitespace", []byte("  \n"), ContentTypeUnknown},
+		{"field number zero", []byte{0x00, 0x01}, ContentTypeUnknown},
+		{"invalid wire type", []byte{0x0f, 0x00}, ContentTypeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectContentType(tt.data); got != tt.want {
+				t.Errorf("DetectContentType = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package messages
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/textproto"
+	"strings"
+)
+
+type contextKey int
+
+const (
+	correlationIDKey contextKey = iota
+	sourceKey
+)
+
+// NewCorrelationID returns a random RFC 4122 version 4 UUID string.
+func NewCorrelationID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("failed to generate correlation id: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// WithCorrelationID returns a copy of ctx carrying the given correlation ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID stored in ctx, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey).(string)
+	return id, ok && id != ""
+}
+
+// WithSource returns a copy of ctx carrying the source of the message being
+// handled.
+func WithSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, sourceKey, source)
+}
+
+// SourceFromContext returns the message source stored in ctx, if any.
+func SourceFromContext(ctx context.Context) (string, bool) {
+	source, ok := ctx.Value(sourceKey).(string)
+	return source, ok && source != ""
+}
+
+// correlationHeaders lists the headers checked by ExtractCorrelationID, in
+// priority order.
+var correlationHeaders = []string{"X-Correlation-ID", "X-Request-ID"}
+
+// ExtractCorrelationID returns the correlation ID carried by inbound HTTP
+// headers: X-Correlation-ID, then X-Request-ID, then the trace ID of a W3C
+// traceparent header. If none is present a new ID is generated.
+func ExtractCorrelationID(headers map[string][]string) string {
+	h := textproto.MIMEHeader(headers)
+	for _, name := range correlationHeaders {
+		if id := strings.TrimSpace(h.Get(name)); id != "" {
+			return id
+		}
+	}
+	if id, ok := traceID(h.Get("Traceparent")); ok {
+		return id
+	}
+	return NewCorrelationID()
+}
+
+// traceID extracts the trace ID from a traceparent header value
+// ("00-<32 hex trace id>-<16 hex parent id>-<2 hex flags>").
+func traceID(traceparent string) (string, bool) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[1]) != 32 {
+		return "", false
+	}
+	id := parts[1]
+	if strings.Trim(id, "0") == "" {
+		return "", false
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", false
+	}
+	return id, true
+}
@@ -0,0 +1,56 @@
+package messages
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestExtractCorrelationID(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	tests := []struct {
+		name    string
+		headers http.Header
+		want    string
+	}{
+		{"correlation id", http.Header{"X-Correlation-Id": {"c-1"}}, "c-1"},
+		{"request id", http.Header{"X-Request-Id": {"r-1"}}, "r-1"},
+		{"traceparent", http.Header{"Traceparent": {traceparent}}, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"correlation id first", http.Header{"X-Correlation-Id": {"c-1"}, "X-Request-Id": {"r-1"}, "Traceparent": {traceparent}}, "c-1"},
+		{"request id before traceparent", http.Header{"X-Request-Id": {"r-1"}, "Traceparent": {traceparent}}, "r-1"},
+		{"non-canonical key", map[string][]string{"x-request-id": {"r-1"}}, ""},
+		{"trimmed", http.Header{"X-Request-Id": {"  r-1 "}}, "r-1"},
+		{"blank falls through", http.Header{"X-Correlation-Id": {" "}, "X-Request-Id": {"r-1"}}, "r-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractCorrelationID(tt.headers)
+			if tt.want == "" {
+				if !uuidV4.MatchString(got) {
+					t.Errorf("ExtractCorrelationID = %q, want a generated UUID", got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ExtractCorrelationID = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractCorrelationIDFallback(t *testing.T) {
+	for _, h := range []http.Header{
+		nil,
+		{},
+		{"Traceparent": {"00-00000000000000000000000000000000-00f067aa0ba902b7-01"}},
+		{"Traceparent": {"00-not-hex-at-all"}},
+		{"Traceparent": {"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}},
+	} {
+		a, b := ExtractCorrelationID(h), ExtractCorrelationID(h)
+		if !uuidV4.MatchString(a) || a == b {
+			t.Errorf("headers %v: generated %q then %q", h, a, b)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// DeadLetterEventType is the event type of dead-letter envelopes.
+const DeadLetterEventType = "dead_letter"
+
+// DeadLetterBody is the message carried by a dead-letter envelope.
+type DeadLetterBody struct {
+	Original json.RawMessage `json:"original"`
+	Reason   string          `json:"reason"`
+	Attempts int             `json:"attempts"`
+}
+
+// DeadLetter wraps a message that failed processing, together with the
+// failure reason, into a dead_letter envelope. The correlation ID and
+// context of the original are kept when present. Dead-lettering an existing
+// dead letter increments Attempts and keeps the innermost original.
+func DeadLetter(original []byte, reason error, source string) (SendingMessage, error) {
+	if !json.Valid(original) {
+		return SendingMessage{}, errors.New("dead letter original is not valid JSON")
+	}
+
+	var orig struct {
+		Head Head `json:"head"`
+		Body struct {
+			Context MessageContext  `json:"context"`
+			Message json.RawMessage `json:"message"`
+		} `json:"body"`
+	}
+	_ = json.Unmarshal(original, &orig)
+
+	body := DeadLetterBody{Original: original, Attempts: 1}
+	if reason != nil {
+		body.Reason = reason.Error()
+	}
+	if orig.Head.Eventtype == DeadLetterEventType {
+		var prev DeadLetterBody
+		if err := json.Unmarshal(orig.Body.Message, &prev); err == nil && len(prev.Original) > 0 {
+			body.Original = prev.Original
+			body.Attempts = prev.Attempts + 1
+		}
+	}
+
+	var m SendingMessage
+	m.Head = Head{
+		Time:          int(now().Unix()),
+		Correlationid: orig.Head.Correlationid,
+		Eventtype:     DeadLetterEventType,
+		Source:        source,
+	}
+	m.Body = SendingMessageBody{
+		Context: orig.Body.Context,
+		Message: body,
+	}
+	return m, nil
+}
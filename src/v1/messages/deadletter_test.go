@@ -0,0 +1,79 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// decodeDeadLetter round-trips m through JSON the way a DLQ consumer sees it.
+func decodeDeadLetter(t *testing.T, m SendingMessage) (Head, MessageContext, DeadLetterBody, []byte) {
+	t.Helper()
+	raw, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env Message[struct {
+		Context MessageContext `json:"context"`
+		Message DeadLetterBody `json:"message"`
+	}]
+	if err := json.Unmarshal(raw, &env); err != nil {
+		t.Fatalf("dead letter is not decodable: %v", err)
+	}
+	return env.Head, env.Body.Context, env.Body.Message, raw
+}
+
+func TestDeadLetterRoundTrip(t *testing.T) {
+	c := useFakeClock(t)
+	original := []byte(`{"head":{"event_type":"order.placed","correlation_id":"c-1","source":"api"},` +
+		`"body":{"context":{"client_id":1,"company_id":2,"instance_id":3},"message":{"qty":0}}}`)
+
+	m, err := DeadLetter(original, errors.New("quantity must be positive"), "orders")
+	if err != nil {
+		t.Fatalf("DeadLetter: %v", err)
+	}
+
+	head, ctx, body, raw := decodeDeadLetter(t, m)
+	if head.Eventtype != DeadLetterEventType || head.Source != "orders" || head.Correlationid != "c-1" {
+		t.Errorf("head = %+v", head)
+	}
+	if int64(head.Time) != c.Now().Unix() {
+		t.Errorf("time = %d", head.Time)
+	}
+	if ctx != (MessageContext{ClientID: 1, CompanyID: 2, InstanceID: 3}) {
+		t.Errorf("context = %+v", ctx)
+	}
+	if body.Reason != "quantity must be positive" || body.Attempts != 1 {
+		t.Errorf("body = %+v", body)
+	}
+	if !bytes.Equal(body.Original, original) {
+		t.Errorf("original = %s, want it unchanged", body.Original)
+	}
+
+	// Failing again on replay keeps the innermost original and counts up.
+	again, err := DeadLetter(raw, errors.New("still broken"), "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, body, _ = decodeDeadLetter(t, again)
+	if body.Attempts != 2 || body.Reason != "still broken" || !bytes.Equal(body.Original, original) {
+		t.Errorf("second dead letter = %+v", body)
+	}
+}
+
+func TestDeadLetterInvalidOriginal(t *testing.T) {
+	if _, err := DeadLetter([]byte(`{"head":`), errors.New("x"), "s"); err == nil {
+		t.Error("DeadLetter accepted invalid JSON")
+	}
+
+	// Valid JSON that isn't an envelope is still wrapped.
+	m, err := DeadLetter([]byte(`"just a string"`), nil, "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, body, _ := decodeDeadLetter(t, m)
+	if string(body.Original) != `"just a string"` || body.Reason != "" {
+		t.Errorf("body = %+v", body)
+	}
+}
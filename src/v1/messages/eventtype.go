@@ -0,0 +1,46 @@
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownEventType is returned for event types that were never registered.
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// EventType is a registered event type. Head.Eventtype stays a plain string
+// on the wire; use Head.EventType and Head.SetEventType to convert.
+type EventType string
+
+var (
+	eventTypesMu sync.RWMutex
+	eventTypes   = make(map[EventType]struct{})
+)
+
+// RegisterEventType makes t acceptable to ParseEventType.
+func RegisterEventType(t EventType) {
+	eventTypesMu.Lock()
+	defer eventTypesMu.Unlock()
+	eventTypes[t] = struct{}{}
+}
+
+// ParseEventType returns s as an EventType if it has been registered.
+func ParseEventType(s string) (EventType, error) {
+	eventTypesMu.RLock()
+	defer eventTypesMu.RUnlock()
+	if _, ok := eventTypes[EventType(s)]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownEventType, s)
+	}
+	return EventType(s), nil
+}
+
+// EventType parses the head's event type against the registry.
+func (h Head) EventType() (EventType, error) {
+	return ParseEventType(h.Eventtype)
+}
+
+// SetEventType sets the head's event type.
+func (h *Head) SetEventType(t EventType) {
+	h.Eventtype = string(t)
+}
@@ -0,0 +1,41 @@
+package messages
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEventTypeRegistry(t *testing.T) {
+	const placed EventType = "test.order.placed"
+	RegisterEventType(placed)
+
+	got, err := ParseEventType("test.order.placed")
+	if err != nil || got != placed {
+		t.Errorf("ParseEventType = %q, %v", got, err)
+	}
+
+	for _, s := range []string{"test.order.plcaed", "", "TEST.ORDER.PLACED"} {
+		if got, err := ParseEventType(s); !errors.Is(err, ErrUnknownEventType) || got != "" {
+			t.Errorf("ParseEventType(%q) = %q, %v, want ErrUnknownEventType", s, got, err)
+		}
+	}
+}
+
+func TestHeadEventType(t *testing.T) {
+	const shipped EventType = "test.order.shipped"
+	RegisterEventType(shipped)
+
+	var h Head
+	h.SetEventType(shipped)
+	if h.Eventtype != "test.order.shipped" {
+		t.Errorf("Eventtype = %q", h.Eventtype)
+	}
+	if got, err := h.EventType(); err != nil || got != shipped {
+		t.Errorf("EventType = %q, %v", got, err)
+	}
+
+	h.Eventtype = "test.order.unknown"
+	if _, err := h.EventType(); !errors.Is(err, ErrUnknownEventType) {
+		t.Errorf("EventType = %v, want ErrUnknownEventType", err)
+	}
+}
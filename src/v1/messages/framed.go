@@ -0,0 +1,79 @@
+package messages
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrTruncatedFrame is returned by DecodeFramed when the input ends inside
+// a frame.
+var ErrTruncatedFrame = errors.New("truncated message frame")
+
+// MaxFrameBytes bounds the payload size DecodeFramed accepts, so a corrupt
+// length prefix can't trigger a huge allocation.
+var MaxFrameBytes = 64 << 20
+
+// EncodeFramed writes msgs to w as length-prefixed frames: a 4-byte
+// big-endian payload length followed by the message JSON.
+func EncodeFramed[T any](w io.Writer, msgs []Message[T]) error {
+	var prefix [4]byte
+	for i, m := range msgs {
+		payload, err := json.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("failed to marshal message %d: %w", i, err)
+		}
+		if uint64(len(payload)) > math.MaxUint32 {
+			return fmt.Errorf("message %d is too large to frame: %d bytes", i, len(payload))
+		}
+		binary.BigEndian.PutUint32(prefix[:], uint32(len(payload)))
+		if _, err := w.Write(prefix[:]); err != nil {
+			return fmt.Errorf("failed to write frame %d: %w", i, err)
+		}
+		if _, err := w.Write(payload); err != nil {
+			return fmt.Errorf("failed to write frame %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// DecodeFramed reads frames written by EncodeFramed until r is exhausted.
+// If the input ends mid-frame it returns the messages decoded so far with
+// an error wrapping ErrTruncatedFrame.
+func DecodeFramed[T any](r io.Reader) ([]Message[T], error) {
+	var msgs []Message[T]
+	var prefix [4]byte
+	for {
+		if _, err := io.ReadFull(r, prefix[:]); err != nil {
+			if err == io.EOF {
+				return msgs, nil
+			}
+			return msgs, framedReadError(len(msgs), err)
+		}
+
+		size := binary.BigEndian.Uint32(prefix[:])
+		if uint64(size) > uint64(MaxFrameBytes) {
+			return msgs, fmt.Errorf("frame after %d messages is %d bytes, above MaxFrameBytes", len(msgs), size)
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return msgs, framedReadError(len(msgs), err)
+		}
+
+		var m Message[T]
+		if err := json.Unmarshal(payload, &m); err != nil {
+			return msgs, fmt.Errorf("failed to unmarshal frame after %d messages: %w", len(msgs), err)
+		}
+		msgs = append(msgs, m)
+	}
+}
+
+func framedReadError(read int, err error) error {
+	if err == io.ErrUnexpectedEOF || err == io.EOF {
+		return fmt.Errorf("%w after %d messages", ErrTruncatedFrame, read)
+	}
+	return fmt.Errorf("failed to read frame after %d messages: %w", read, err)
+}
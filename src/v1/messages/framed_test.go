@@ -0,0 +1,119 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type framedBody struct {
+	N    int    `json:"n"`
+	Text string `json:"text"`
+}
+
+func framedMessages() []Message[framedBody] {
+	return []Message[framedBody]{
+		{Head: Head{Eventtype: "a", Correlationid: "c-1"}, Body: framedBody{N: 1, Text: "first"}},
+		{Head: Head{Eventtype: "b", Source: "api"}, Body: framedBody{N: 2, Text: strings.Repeat("x", 1000)}},
+		{Head: Head{Eventtype: "c"}, Body: framedBody{N: 3, Text: "ünïcode\n"}},
+	}
+}
+
+func TestFramedRoundTrip(t *testing.T) {
+	msgs := framedMessages()
+	var buf bytes.Buffer
+	if err := EncodeFramed(&buf, msgs); err != nil {
+		t.Fatalf("EncodeFramed: %v", err)
+	}
+
+	// Each frame is a big-endian length followed by that many bytes.
+	first := binary.BigEndian.Uint32(buf.Bytes()[:4])
+	if first == 0 || buf.Bytes()[4] != '{' || buf.Bytes()[3+first] != '}' {
+		t.Errorf("first frame prefix %d does not match its payload", first)
+	}
+
+	got, err := DecodeFramed[framedBody](&buf)
+	if err != nil {
+		t.Fatalf("DecodeFramed: %v", err)
+	}
+	if !reflect.DeepEqual(got, msgs) {
+		t.Errorf("decoded %+v, want %+v", got, msgs)
+	}
+}
+
+func TestFramedEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeFramed[framedBody](&buf, nil); err != nil || buf.Len() != 0 {
+		t.Fatalf("EncodeFramed(nil) wrote %d bytes, %v", buf.Len(), err)
+	}
+	got, err := DecodeFramed[framedBody](&buf)
+	if err != nil || len(got) != 0 {
+		t.Errorf("DecodeFramed(empty) = %v, %v", got, err)
+	}
+}
+
+func TestDecodeFramedTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeFramed(&buf, framedMessages()); err != nil {
+		t.Fatal(err)
+	}
+	full := buf.Bytes()
+	firstEnd := 4 + int(binary.BigEndian.Uint32(full))
+
+	tests := []struct {
+		name string
+		cut  int
+		read int
+	}{
+		{"inside first prefix", 2, 0},
+		{"after first prefix", 4, 0},
+		{"inside first payload", 10, 0},
+		{"inside second prefix", firstEnd + 3, 1},
+		{"inside second payload", firstEnd + 20, 1},
+		{"one byte short", len(full) - 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeFramed[framedBody](bytes.NewReader(full[:tt.cut]))
+			if !errors.Is(err, ErrTruncatedFrame) {
+				t.Fatalf("err = %v, want ErrTruncatedFrame", err)
+			}
+			if len(got) != tt.read {
+				t.Errorf("decoded %d messages, want %d", len(got), tt.read)
+			}
+			if want := "after " + strconv.Itoa(tt.read) + " messages"; !strings.Contains(err.Error(), want) {
+				t.Errorf("err = %q, want it to mention %q", err, want)
+			}
+		})
+	}
+}
+
+func TestDecodeFramedRejects(t *testing.T) {
+	defer func(n int) { MaxFrameBytes = n }(MaxFrameBytes)
+	MaxFrameBytes = 16
+
+	oversized := []byte{0, 0, 1, 0}
+	if _, err := DecodeFramed[framedBody](bytes.NewReader(oversized)); err == nil || errors.Is(err, ErrTruncatedFrame) {
+		t.Errorf("oversized frame: err = %v", err)
+	}
+
+	bad := append([]byte{0, 0, 0, 3}, "{x}"...)
+	if _, err := DecodeFramed[framedBody](bytes.NewReader(bad)); err == nil || errors.Is(err, ErrTruncatedFrame) {
+		t.Errorf("invalid JSON frame: err = %v", err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, io.ErrClosedPipe }
+
+func TestEncodeFramedWriteError(t *testing.T) {
+	if err := EncodeFramed(failingWriter{}, framedMessages()); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("EncodeFramed = %v, want the writer's error", err)
+	}
+}
@@ -0,0 +1,65 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// HeartbeatEventType is the event type of liveness messages.
+const HeartbeatEventType = "heartbeat"
+
+// HeartbeatVersion is reported by NewHeartbeat; empty means the main
+// module version from the build info.
+var HeartbeatVersion string
+
+// processStart is the reference point for heartbeat uptime. It uses the
+// wall clock rather than the package clock, which isn't set up yet here.
+var processStart = time.Now()
+
+// HeartbeatBody is the message carried by a heartbeat.
+type HeartbeatBody struct {
+	// Uptime is the number of seconds since the process started.
+	Uptime  int64  `json:"uptime"`
+	Version string `json:"version"`
+}
+
+// NewHeartbeat returns a heartbeat message from source reporting the
+// process uptime and HeartbeatVersion.
+func NewHeartbeat(source string) SendingMessage {
+	version := HeartbeatVersion
+	if version == "" {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			version = info.Main.Version
+		}
+	}
+
+	var m SendingMessage
+	m.Head = Head{
+		Time:          int(now().Unix()),
+		Correlationid: NewCorrelationID(),
+		Eventtype:     HeartbeatEventType,
+		Source:        source,
+	}
+	m.Body = SendingMessageBody{Message: HeartbeatBody{
+		Uptime:  int64(time.Since(processStart).Seconds()),
+		Version: version,
+	}}
+	return m
+}
+
+// DecodeHeartbeat parses a heartbeat produced by NewHeartbeat, returning its
+// head and body. It fails if raw is not a heartbeat message.
+func DecodeHeartbeat(raw []byte) (Head, HeartbeatBody, error) {
+	var m Message[struct {
+		Message HeartbeatBody `json:"message"`
+	}]
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return Head{}, HeartbeatBody{}, fmt.Errorf("failed to unmarshal heartbeat: %w", err)
+	}
+	if m.Head.Eventtype != HeartbeatEventType {
+		return Head{}, HeartbeatBody{}, fmt.Errorf("not a heartbeat: event type %q", m.Head.Eventtype)
+	}
+	return m.Head, m.Body.Message, nil
+}
@@ -0,0 +1,77 @@
+package messages
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHeartbeatRoundTrip(t *testing.T) {
+	c := useFakeClock(t)
+	defer func(v string) { HeartbeatVersion = v }(HeartbeatVersion)
+	HeartbeatVersion = "v1.2.3"
+
+	m := NewHeartbeat("billing")
+	raw, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, body, err := DecodeHeartbeat(raw)
+	if err != nil {
+		t.Fatalf("DecodeHeartbeat: %v", err)
+	}
+	if head.Eventtype != HeartbeatEventType || head.Source != "billing" || head.Correlationid == "" {
+		t.Errorf("head = %+v", head)
+	}
+	if head.Time != int(c.Now().Unix()) {
+		t.Errorf("time = %d, want the package clock's %d", head.Time, c.Now().Unix())
+	}
+	if body.Version != "v1.2.3" || body.Uptime < 0 {
+		t.Errorf("body = %+v", body)
+	}
+	if !reflect.DeepEqual(head, m.Head) {
+		t.Errorf("decoded head %+v, sent %+v", head, m.Head)
+	}
+}
+
+func TestHeartbeatUptime(t *testing.T) {
+	defer func(t time.Time) { processStart = t }(processStart)
+	processStart = time.Now().Add(-90 * time.Second)
+
+	_, body, err := DecodeHeartbeat(mustMarshal(t, NewHeartbeat("api")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body.Uptime < 90 || body.Uptime > 91 {
+		t.Errorf("uptime = %d, want 90", body.Uptime)
+	}
+}
+
+func TestDecodeHeartbeatRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"other event type", `{"head":{"event_type":"order.placed"},"body":{"message":{"uptime":1}}}`},
+		{"invalid JSON", `{"head":`},
+		{"wrong body type", `{"head":{"event_type":"heartbeat"},"body":{"message":{"uptime":"long"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := DecodeHeartbeat([]byte(tt.raw)); err == nil {
+				t.Error("DecodeHeartbeat accepted the input")
+			}
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
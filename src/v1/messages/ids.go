@@ -0,0 +1,67 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ID is a numeric identifier that decodes from either a JSON number or a
+// JSON string holding a number, since some producers quote their IDs.
+type ID uint
+
+func (id *ID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	v, err := strconv.ParseUint(s, 10, strconv.IntSize)
+	if err != nil {
+		return fmt.Errorf("invalid id %s: %w", data, err)
+	}
+	*id = ID(v)
+	return nil
+}
+
+// UnmarshalJSON accepts string or numeric IDs.
+func (c *MessageContext) UnmarshalJSON(data []byte) error {
+	type alias MessageContext
+	aux := struct {
+		*alias
+		ClientID   ID `json:"client_id"`
+		CompanyID  ID `json:"company_id"`
+		InstanceID ID `json:"instance_id"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	c.ClientID = uint(aux.ClientID)
+	c.CompanyID = uint(aux.CompanyID)
+	c.InstanceID = uint(aux.InstanceID)
+	return nil
+}
+
+// UnmarshalJSON accepts string or numeric IDs.
+func (b *IncomingMessageBody) UnmarshalJSON(data []byte) error {
+	type alias IncomingMessageBody
+	aux := struct {
+		*alias
+		ClientID   ID `json:"client_id"`
+		CompanyID  ID `json:"company_id"`
+		InstanceID ID `json:"instance_id"`
+	}{alias: (*alias)(b)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	b.ClientID = uint(aux.ClientID)
+	b.CompanyID = uint(aux.CompanyID)
+	b.InstanceID = uint(aux.InstanceID)
+	return nil
+}
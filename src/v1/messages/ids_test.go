@@ -0,0 +1,75 @@
+package messages
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestIDUnmarshal(t *testing.T) {
+	maxID := strconv.FormatUint(math.MaxUint, 10)
+	tests := []struct {
+		in   string
+		want ID
+	}{
+		{`42`, 42},
+		{`"42"`, 42},
+		{` 7 `, 7},
+		{maxID, math.MaxUint},
+		{`"` + maxID + `"`, math.MaxUint},
+		{`null`, 5}, // left unchanged
+	}
+	for _, tt := range tests {
+		id := ID(5)
+		if err := json.Unmarshal([]byte(tt.in), &id); err != nil || id != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, %v, want %d", tt.in, id, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{`-1`, `"abc"`, `1.5`, `1e3`, `""`, `true`, `"` + maxID + `0"`} {
+		var id ID
+		if err := json.Unmarshal([]byte(in), &id); err == nil {
+			t.Errorf("Unmarshal(%s) = %d, want an error", in, id)
+		}
+	}
+}
+
+func TestMessageContextTolerantIDs(t *testing.T) {
+	var m SendingMessage
+	data := `{"head":{"event_type":"e"},"body":{"context":` +
+		`{"client_id":"12","company_id":` + strconv.FormatUint(math.MaxUint, 10) + `,"instance_id":3},"message":"hi"}}`
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	want := MessageContext{ClientID: 12, CompanyID: math.MaxUint, InstanceID: 3}
+	if m.Body.Context != want {
+		t.Errorf("context = %+v, want %+v", m.Body.Context, want)
+	}
+	if m.Body.Message != "hi" {
+		t.Errorf("message = %v", m.Body.Message)
+	}
+}
+
+func TestIncomingMessageBodyTolerantIDs(t *testing.T) {
+	// 2^53+1 would round through float64; 32-bit targets use the widest uint.
+	bigID := "9007199254740993"
+	if strconv.IntSize == 32 {
+		bigID = strconv.FormatUint(math.MaxUint, 10)
+	}
+
+	var m IncomingMessage
+	data := `{"head":{"event_type":"e"},"body":` +
+		`{"client_id":1,"company_id":"` + bigID + `","instance_id":"4","message":"hi","link":"l"}}`
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	b := m.Body
+	if b.ClientID != 1 || strconv.FormatUint(uint64(b.CompanyID), 10) != bigID || b.InstanceID != 4 || b.Message != "hi" || b.Link != "l" {
+		t.Errorf("body = %+v", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"body":{"client_id":"x"}}`), &m); err == nil {
+		t.Error("invalid ID accepted")
+	}
+}
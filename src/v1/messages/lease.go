@@ -0,0 +1,40 @@
+package messages
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LeaseWarnFraction is the share of the lease after which WithLease logs a
+// warning if processing hasn't finished.
+var LeaseWarnFraction = 0.8
+
+// WithLease derives a context cancelled at deadline, typically the broker's
+// visibility timeout, so handlers stop before the message is redelivered.
+// If processing is still running after LeaseWarnFraction of the lease, a
+// warning is logged. Call the returned cancel func when processing ends.
+// The lease is measured with the package clock (see SetClock).
+func WithLease(ctx context.Context, l *logrus.Logger, deadline time.Time) (context.Context, context.CancelFunc) {
+	start := now()
+	lease := deadline.Sub(start)
+	leaseCtx, cancel := context.WithTimeout(ctx, lease)
+
+	warnAfter := time.Duration(float64(lease) * LeaseWarnFraction)
+	timer := time.AfterFunc(warnAfter, func() {
+		if leaseCtx.Err() != nil {
+			return
+		}
+		l.WithFields(logrus.Fields{
+			"lease_ms":     lease.Milliseconds(),
+			"elapsed_ms":   now().Sub(start).Milliseconds(),
+			"remaining_ms": deadline.Sub(now()).Milliseconds(),
+		}).Warn("message processing is close to its lease deadline")
+	})
+
+	return leaseCtx, func() {
+		timer.Stop()
+		cancel()
+	}
+}
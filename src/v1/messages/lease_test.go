@@ -0,0 +1,52 @@
+package messages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestWithLeaseWarnsNearDeadline(t *testing.T) {
+	clk := useFakeClock(t)
+	l, hook := test.NewNullLogger()
+
+	old := LeaseWarnFraction
+	LeaseWarnFraction = 0.5
+	defer func() { LeaseWarnFraction = old }()
+
+	ctx, cancel := WithLease(context.Background(), l, clk.Now().Add(40*time.Millisecond))
+	defer cancel()
+	clk.Advance(30 * time.Millisecond)
+
+	<-ctx.Done()
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("ctx.Err() = %v, want DeadlineExceeded", ctx.Err())
+	}
+
+	entry := hook.LastEntry()
+	if entry == nil || entry.Level != logrus.WarnLevel {
+		t.Fatalf("expected a warning, got %v", hook.AllEntries())
+	}
+	want := logrus.Fields{"lease_ms": int64(40), "elapsed_ms": int64(30), "remaining_ms": int64(10)}
+	for k, v := range want {
+		if entry.Data[k] != v {
+			t.Errorf("%s = %v, want %v", k, entry.Data[k], v)
+		}
+	}
+}
+
+func TestWithLeaseNoWarningWhenDoneEarly(t *testing.T) {
+	clk := useFakeClock(t)
+	l, hook := test.NewNullLogger()
+
+	_, cancel := WithLease(context.Background(), l, clk.Now().Add(40*time.Millisecond))
+	cancel()
+	time.Sleep(60 * time.Millisecond)
+
+	if n := len(hook.AllEntries()); n != 0 {
+		t.Errorf("got %d entries after finishing early, want none", n)
+	}
+}
@@ -0,0 +1,30 @@
+package messages
+
+import (
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MessageContextLabels are the metric label names matching the order of
+// MessageContext.LabelValues.
+var MessageContextLabels = []string{"client_id", "company_id", "instance_id"}
+
+// Fields returns the tenant IDs as log fields.
+func (c MessageContext) Fields() logrus.Fields {
+	return logrus.Fields{
+		"client_id":   c.ClientID,
+		"company_id":  c.CompanyID,
+		"instance_id": c.InstanceID,
+	}
+}
+
+// LabelValues returns the tenant IDs as metric label values, in the order of
+// MessageContextLabels.
+func (c MessageContext) LabelValues() []string {
+	return []string{
+		strconv.FormatUint(uint64(c.ClientID), 10),
+		strconv.FormatUint(uint64(c.CompanyID), 10),
+		strconv.FormatUint(uint64(c.InstanceID), 10),
+	}
+}
@@ -0,0 +1,38 @@
+package messages
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestMessageContextFields(t *testing.T) {
+	c := MessageContext{ClientID: 1, CompanyID: 22, InstanceID: 4294967295}
+
+	want := logrus.Fields{"client_id": uint(1), "company_id": uint(22), "instance_id": uint(4294967295)}
+	if got := c.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields = %v, want %v", got, want)
+	}
+	if got := c.LabelValues(); !reflect.DeepEqual(got, []string{"1", "22", "4294967295"}) {
+		t.Errorf("LabelValues = %v", got)
+	}
+
+	// Label names and values line up, and match the field keys.
+	fields := c.Fields()
+	for i, name := range MessageContextLabels {
+		if _, ok := fields[name]; !ok {
+			t.Errorf("label %q is not a field key", name)
+		}
+		if i >= len(c.LabelValues()) {
+			t.Errorf("no value for label %q", name)
+		}
+	}
+	if len(MessageContextLabels) != len(fields) {
+		t.Errorf("%d labels for %d fields", len(MessageContextLabels), len(fields))
+	}
+
+	if got := (MessageContext{}).LabelValues(); !reflect.DeepEqual(got, []string{"0", "0", "0"}) {
+		t.Errorf("zero LabelValues = %v", got)
+	}
+}
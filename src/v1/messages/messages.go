@@ -1,16 +1,44 @@
 package messages
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"reflect"
 )
 
 type Head struct {
-	Destination   string `json:"destination"`
-	Time          int    `json:"time"`
-	Correlationid string `json:"correlation_id"`
-	Eventtype     string `json:"event_type"`
-	Source        string `json:"source"`
+	Destination   string            `json:"destination"`
+	Time          int               `json:"time"`
+	Correlationid string            `json:"correlation_id"`
+	Eventtype     string            `json:"event_type"`
+	Source        string            `json:"source"`
+	Trace         []TraceHop        `json:"trace,omitempty"`
+	Sensitive     bool              `json:"sensitive,omitempty"`
+	Version       int               `json:"version,omitempty"`
+	Baggage       map[string]string `json:"baggage,omitempty"`
+	Sequence      int               `json:"sequence,omitempty"`
+	Final         bool              `json:"final,omitempty"`
+	Priority      int               `json:"priority,omitempty"`
+	Checksum      string            `json:"checksum,omitempty"`
+	Attempt       int               `json:"attempt,omitempty"`
+	RoutingKey    string            `json:"routing_key,omitempty"`
+}
+
+// SetBaggage attaches a key/value pair that travels with the message.
+func (h *Head) SetBaggage(key, value string) {
+	if h.Baggage == nil {
+		h.Baggage = make(map[string]string)
+	}
+	h.Baggage[key] = value
+}
+
+// TraceHop records a service that handled a message and when (unix seconds).
+type TraceHop struct {
+	Source string `json:"source"`
+	Time   int    `json:"time"`
 }
 
 type Message[T any] struct {
@@ -60,6 +88,77 @@ func GetEventType(data []byte) (string, error) {
 	return ho.Head.Eventtype, nil
 }
 
+var (
+	// ErrMissingHead is returned by ParseHead when the envelope has no head.
+	ErrMissingHead = errors.New("message has no head")
+	// ErrEmptyEventType is returned by ParseHead when the head has no event type.
+	ErrEmptyEventType = errors.New("message head has empty event type")
+)
+
+// ParseHead decodes the full head, unlike GetEventType rejecting envelopes
+// without a head object (ErrMissingHead) or with an empty event type
+// (ErrEmptyEventType).
+func ParseHead(data []byte) (Head, error) {
+	var env struct {
+		Head *Head `json:"head"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Head{}, fmt.Errorf("failed to unmarshal message head: %w", err)
+	}
+	if env.Head == nil {
+		return Head{}, ErrMissingHead
+	}
+	if env.Head.Eventtype == "" {
+		return *env.Head, ErrEmptyEventType
+	}
+	return *env.Head, nil
+}
+
+// headPeek is a minimal head shim used by Peek.
+type headPeek struct {
+	Head struct {
+		Eventtype     string `json:"event_type"`
+		Correlationid string `json:"correlation_id"`
+		Source        string `json:"source"`
+	} `json:"head"`
+}
+
+// Peek extracts the routing fields of the head in a single unmarshal.
+func Peek(data []byte) (eventType, correlationID, source string, err error) {
+	var hp headPeek
+	if err := json.Unmarshal(data, &hp); err != nil {
+		return "", "", "", fmt.Errorf("failed to unmarshal head-only message: %w", err)
+	}
+	return hp.Head.Eventtype, hp.Head.Correlationid, hp.Head.Source, nil
+}
+
 func Convert(raw []byte, out interface{}) error {
 	return json.Unmarshal(raw, out)
 }
+
+// ConvertStrict is like Convert but rejects unknown fields and trailing data.
+// It decodes into a fresh value so out is never left partially populated:
+// on error out is reset to its zero value. Convert stays lenient.
+func ConvertStrict(raw []byte, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("convert target must be a non-nil pointer, got %T", out)
+	}
+	target := rv.Elem()
+	tmp := reflect.New(target.Type())
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(tmp.Interface())
+	if err == nil {
+		if _, tokErr := dec.Token(); !errors.Is(tokErr, io.EOF) {
+			err = errors.New("unexpected data after JSON document")
+		}
+	}
+	if err != nil {
+		target.Set(reflect.Zero(target.Type()))
+		return err
+	}
+	target.Set(tmp.Elem())
+	return nil
+}
@@ -0,0 +1,144 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+var peekInput = []byte(`{"head":{"destination":"billing","time":1700000000,` +
+	`"correlation_id":"c-42","event_type":"invoice.paid","source":"api",` +
+	`"trace":[{"source":"api","time":1700000000}]},` +
+	`"body":{"context":{"client_id":1,"company_id":2,"instance_id":3},"message":{"amount":100}}}`)
+
+func TestPeek(t *testing.T) {
+	eventType, correlationID, source, err := Peek(peekInput)
+	if err != nil {
+		t.Fatalf("Peek: %v", err)
+	}
+	if eventType != "invoice.paid" || correlationID != "c-42" || source != "api" {
+		t.Errorf("Peek = %q, %q, %q", eventType, correlationID, source)
+	}
+
+	eventType, correlationID, source, err = Peek([]byte(`{"body":{}}`))
+	if err != nil || eventType != "" || correlationID != "" || source != "" {
+		t.Errorf("Peek without head = %q, %q, %q, %v", eventType, correlationID, source, err)
+	}
+
+	if _, _, _, err := Peek([]byte(`{"head":`)); err == nil {
+		t.Error("Peek accepted truncated JSON")
+	}
+}
+
+func BenchmarkPeek(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, err := Peek(peekInput); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetEventTypeThenHead is the two-pass path Peek replaces.
+func BenchmarkGetEventTypeThenHead(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := GetEventType(peekInput); err != nil {
+			b.Fatal(err)
+		}
+		var env struct {
+			Head Head `json:"head"`
+		}
+		if err := json.Unmarshal(peekInput, &env); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestConvertStrict(t *testing.T) {
+	type body struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	var ok Message[body]
+	if err := ConvertStrict([]byte(`{"head":{"event_type":"e"},"body":{"name":"a","count":2}}`), &ok); err != nil {
+		t.Fatalf("ConvertStrict: %v", err)
+	}
+	if ok.Head.Eventtype != "e" || ok.Body != (body{Name: "a", Count: 2}) {
+		t.Errorf("decoded %+v", ok)
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"unknown field", `{"head":{"event_type":"e"},"body":{"name":"a","extra":1}}`},
+		{"mid-document type error", `{"head":{"event_type":"e"},"body":{"name":"a","count":"two"}}`},
+		{"truncated", `{"head":{"event_type":"e"},"body":{"name":"a"`},
+		{"trailing data", `{"head":{"event_type":"e"},"body":{}} {}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := Message[body]{Body: body{Name: "stale", Count: 9}}
+			if err := ConvertStrict([]byte(tt.raw), &out); err == nil {
+				t.Fatal("ConvertStrict accepted the input")
+			}
+			if out.Head.Eventtype != "" || out.Body != (body{}) {
+				t.Errorf("out not zeroed on error: %+v", out)
+			}
+		})
+	}
+
+	// Convert stays lenient about unknown fields.
+	var lenient Message[body]
+	if err := Convert([]byte(tests[0].raw), &lenient); err != nil || lenient.Body.Name != "a" {
+		t.Errorf("Convert = %+v, %v", lenient, err)
+	}
+}
+
+func TestConvertStrictRequiresPointer(t *testing.T) {
+	var m Message[any]
+	if err := ConvertStrict([]byte(`{}`), m); err == nil {
+		t.Error("ConvertStrict accepted a non-pointer")
+	}
+	if err := ConvertStrict([]byte(`{}`), (*Message[any])(nil)); err == nil {
+		t.Error("ConvertStrict accepted a nil pointer")
+	}
+}
+
+func TestParseHead(t *testing.T) {
+	h, err := ParseHead([]byte(`{"head":{"event_type":"e","source":"api","version":2},"body":{}}`))
+	if err != nil || h.Eventtype != "e" || h.Source != "api" || h.Version != 2 {
+		t.Errorf("ParseHead = %+v, %v", h, err)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"missing head", `{"body":{}}`, ErrMissingHead},
+		{"null head", `{"head":null,"body":{}}`, ErrMissingHead},
+		{"empty event type", `{"head":{"source":"api"}}`, ErrEmptyEventType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseHead([]byte(tt.data)); !errors.Is(err, tt.want) {
+				t.Errorf("ParseHead = %v, want %v", err, tt.want)
+			}
+			// GetEventType stays lenient.
+			if et, err := GetEventType([]byte(tt.data)); et != "" || err != nil {
+				t.Errorf("GetEventType = %q, %v", et, err)
+			}
+		})
+	}
+
+	// The head is still returned alongside ErrEmptyEventType.
+	if h, _ := ParseHead([]byte(`{"head":{"source":"api"}}`)); h.Source != "api" {
+		t.Errorf("head = %+v", h)
+	}
+	if _, err := ParseHead([]byte(`[]`)); err == nil || errors.Is(err, ErrMissingHead) {
+		t.Errorf("ParseHead(array) = %v, want a decode error", err)
+	}
+}
@@ -0,0 +1,78 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// MigrationFunc upgrades a body by exactly one version.
+type MigrationFunc func(body json.RawMessage) (json.RawMessage, error)
+
+type migrationKey struct {
+	eventType string
+	from      int
+}
+
+// Migrator maps (event type, version) pairs to upgrade functions.
+type Migrator struct {
+	mu    sync.RWMutex
+	steps map[migrationKey]MigrationFunc
+}
+
+// NewMigrator creates an empty migration registry.
+func NewMigrator() *Migrator {
+	return &Migrator{steps: make(map[migrationKey]MigrationFunc)}
+}
+
+// DefaultMigrator is the registry used by RegisterMigration and Migrate.
+var DefaultMigrator = NewMigrator()
+
+// Register adds fn as the upgrade from version `from` to `from+1` for
+// eventType, replacing any previous registration.
+func (m *Migrator) Register(eventType string, from int, fn MigrationFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.steps[migrationKey{eventType, from}] = fn
+}
+
+// Migrate applies registered upgrades to raw until no migration exists for
+// its current version. Messages that need no migration are returned as is.
+func (m *Migrator) Migrate(raw []byte) ([]byte, error) {
+	head, body, err := UnwrapRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	migrated := false
+	for {
+		fn, ok := m.steps[migrationKey{head.Eventtype, head.Version}]
+		if !ok {
+			break
+		}
+		body, err = fn(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate %q from version %d: %w", head.Eventtype, head.Version, err)
+		}
+		head.Version++
+		migrated = true
+	}
+
+	if !migrated {
+		return raw, nil
+	}
+	return WrapRaw(head, body), nil
+}
+
+// RegisterMigration registers fn with DefaultMigrator.
+func RegisterMigration(eventType string, from int, fn MigrationFunc) {
+	DefaultMigrator.Register(eventType, from, fn)
+}
+
+// Migrate upgrades raw using DefaultMigrator.
+func Migrate(raw []byte) ([]byte, error) {
+	return DefaultMigrator.Migrate(raw)
+}
@@ -0,0 +1,92 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// renameField returns a migration that moves body[from] to body[to].
+func renameField(from, to string) MigrationFunc {
+	return func(body json.RawMessage) (json.RawMessage, error) {
+		var m map[string]any
+		if err := json.Unmarshal(body, &m); err != nil {
+			return nil, err
+		}
+		m[to] = m[from]
+		delete(m, from)
+		return json.Marshal(m)
+	}
+}
+
+func TestMigratorChain(t *testing.T) {
+	m := NewMigrator()
+	m.Register("user.created", 0, renameField("name", "full_name"))
+	m.Register("user.created", 1, renameField("full_name", "display_name"))
+
+	out, err := m.Migrate([]byte(`{"head":{"event_type":"user.created","correlation_id":"c-1"},"body":{"name":"Ann"}}`))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	var got Message[map[string]string]
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("migrated message is invalid: %s", out)
+	}
+	if got.Head.Version != 2 || got.Head.Correlationid != "c-1" {
+		t.Errorf("head = %+v, want version 2 with the rest kept", got.Head)
+	}
+	if len(got.Body) != 1 || got.Body["display_name"] != "Ann" {
+		t.Errorf("body = %v", got.Body)
+	}
+
+	// Starting part way through the chain applies only the remaining step.
+	out, err = m.Migrate([]byte(`{"head":{"event_type":"user.created","version":1},"body":{"full_name":"Bob"}}`))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	_ = json.Unmarshal(out, &got)
+	if got.Head.Version != 2 || got.Body["display_name"] != "Bob" {
+		t.Errorf("from v1 = %+v", got)
+	}
+}
+
+func TestMigratorUnchanged(t *testing.T) {
+	m := NewMigrator()
+	m.Register("user.created", 0, renameField("name", "full_name"))
+
+	for _, raw := range []string{
+		`{"head":{"event_type":"user.created","version":1},"body":{"full_name":"Ann"}}`,
+		`{"head":{"event_type":"other"},"body":{ "kept" : "as is" }}`,
+	} {
+		out, err := m.Migrate([]byte(raw))
+		if err != nil || !bytes.Equal(out, []byte(raw)) {
+			t.Errorf("Migrate(%s) = %s, %v, want the input back", raw, out, err)
+		}
+	}
+}
+
+func TestMigratorErrors(t *testing.T) {
+	m := NewMigrator()
+	boom := errors.New("boom")
+	m.Register("e", 0, func(json.RawMessage) (json.RawMessage, error) { return nil, boom })
+
+	if _, err := m.Migrate([]byte(`{"head":{"event_type":"e"},"body":{}}`)); !errors.Is(err, boom) {
+		t.Errorf("Migrate = %v, want the step's error", err)
+	}
+	if _, err := m.Migrate([]byte(`not json`)); err == nil {
+		t.Error("Migrate accepted invalid JSON")
+	}
+}
+
+func TestDefaultMigrator(t *testing.T) {
+	defer func(m *Migrator) { DefaultMigrator = m }(DefaultMigrator)
+	DefaultMigrator = NewMigrator()
+
+	RegisterMigration("e", 0, renameField("a", "b"))
+	out, err := Migrate([]byte(`{"head":{"event_type":"e"},"body":{"a":1}}`))
+	if err != nil || !bytes.Contains(out, []byte(`"body":{"b":1}`)) {
+		t.Errorf("Migrate = %s, %v", out, err)
+	}
+}
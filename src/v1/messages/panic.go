@@ -0,0 +1,38 @@
+package messages
+
+import "fmt"
+
+// PanicEventType is the event type of crash-report messages.
+const PanicEventType = "panic"
+
+// MaxPanicStackBytes bounds the stack trace carried by NewPanicMessage.
+// Negative values are treated as zero.
+var MaxPanicStackBytes = 16 << 10
+
+// PanicBody is the message carried by a crash report.
+type PanicBody struct {
+	Value     string `json:"value"`
+	Stack     string `json:"stack"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// NewPanicMessage packages a recovered panic value and its stack trace into
+// a panic message from source. The stack is cut at MaxPanicStackBytes.
+func NewPanicMessage(source string, recovered any, stack []byte) SendingMessage {
+	body := PanicBody{Value: fmt.Sprint(recovered)}
+	if limit := max(MaxPanicStackBytes, 0); len(stack) > limit {
+		stack = stack[:limit]
+		body.Truncated = true
+	}
+	body.Stack = string(stack)
+
+	var m SendingMessage
+	m.Head = Head{
+		Time:          int(now().Unix()),
+		Correlationid: NewCorrelationID(),
+		Eventtype:     PanicEventType,
+		Source:        source,
+	}
+	m.Body = SendingMessageBody{Message: body}
+	return m
+}
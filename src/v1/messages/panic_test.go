@@ -0,0 +1,48 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestNewPanicMessageRoundTrip(t *testing.T) {
+	c := useFakeClock(t)
+	stack := debug.Stack()
+
+	m := NewPanicMessage("orders", errors.New("nil map write"), stack)
+	raw, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Message[struct {
+		Message PanicBody `json:"message"`
+	}]
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("panic message is not decodable: %v", err)
+	}
+	h := got.Head
+	if h.Eventtype != PanicEventType || h.Source != "orders" || h.Correlationid == "" || int64(h.Time) != c.Now().Unix() {
+		t.Errorf("head = %+v", h)
+	}
+	body := got.Body.Message
+	if body.Value != "nil map write" || body.Stack != string(stack) || body.Truncated {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestNewPanicMessageBoundsStack(t *testing.T) {
+	defer func(n int) { MaxPanicStackBytes = n }(MaxPanicStackBytes)
+
+	stack := []byte(strings.Repeat("x", 100))
+	for limit, want := range map[int]int{10: 10, 100: 100, 0: 0, -1: 0} {
+		MaxPanicStackBytes = limit
+		body := NewPanicMessage("s", "boom", stack).Body.Message.(PanicBody)
+		if len(body.Stack) != want || body.Truncated != (want < len(stack)) {
+			t.Errorf("limit %d: stack of %d bytes, truncated = %v", limit, len(body.Stack), body.Truncated)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package messages
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned when pushing to a full PriorityQueue.
+var ErrQueueFull = errors.New("priority queue is full")
+
+// PriorityQueue is a bounded queue of messages ordered by Head.Priority
+// (higher first, FIFO among equals).
+//
+// To avoid starving low-priority messages, a waiting message gains one
+// priority level per aging interval. Because every queued message ages at
+// the same rate, this is equivalent to a fixed ordering key computed at
+// push time, so the queue never needs re-sorting.
+type PriorityQueue[T any] struct {
+	mu       sync.Mutex
+	items    priorityItems[T]
+	capacity int
+	aging    time.Duration
+	epoch    time.Time
+	seq      uint64
+}
+
+type priorityItem[T any] struct {
+	msg   Message[T]
+	score float64
+	seq   uint64
+}
+
+type priorityItems[T any] []priorityItem[T]
+
+func (p priorityItems[T]) Len() int { return len(p) }
+func (p priorityItems[T]) Less(i, j int) bool {
+	if p[i].score != p[j].score {
+		return p[i].score > p[j].score
+	}
+	return p[i].seq < p[j].seq
+}
+func (p priorityItems[T]) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p *priorityItems[T]) Push(x any)   { *p = append(*p, x.(priorityItem[T])) }
+func (p *priorityItems[T]) Pop() any {
+	old := *p
+	item := old[len(old)-1]
+	*p = old[:len(old)-1]
+	return item
+}
+
+// NewPriorityQueue creates a queue holding at most capacity messages. A
+// zero aging interval disables aging.
+func NewPriorityQueue[T any](capacity int, aging time.Duration) *PriorityQueue[T] {
+	return &PriorityQueue[T]{capacity: capacity, aging: aging, epoch: now()}
+}
+
+// Push enqueues msg, or returns ErrQueueFull.
+func (q *PriorityQueue[T]) Push(msg Message[T]) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) >= q.capacity {
+		return ErrQueueFull
+	}
+	score := float64(msg.Head.Priority)
+	if q.aging > 0 {
+		score -= float64(now().Sub(q.epoch)) / float64(q.aging)
+	}
+	q.seq++
+	heap.Push(&q.items, priorityItem[T]{msg: msg, score: score, seq: q.seq})
+	return nil
+}
+
+// Pop removes and returns the most urgent message.
+func (q *PriorityQueue[T]) Pop() (Message[T], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return Message[T]{}, false
+	}
+	return heap.Pop(&q.items).(priorityItem[T]).msg, true
+}
+
+// Len returns the number of queued messages.
+func (q *PriorityQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// PriorityDispatcher buffers pending messages in a PriorityQueue and hands
+// them to a handler most urgent first.
+type PriorityDispatcher[T any] struct {
+	queue  *PriorityQueue[T]
+	handle func(Message[T]) error
+}
+
+// NewPriorityDispatcher creates a dispatcher buffering up to capacity
+// messages; see PriorityQueue for the aging semantics.
+func NewPriorityDispatcher[T any](capacity int, aging time.Duration, handle func(Message[T]) error) *PriorityDispatcher[T] {
+	return &PriorityDispatcher[T]{
+		queue:  NewPriorityQueue[T](capacity, aging),
+		handle: handle,
+	}
+}
+
+// Enqueue buffers msg for the next Drain.
+func (d *PriorityDispatcher[T]) Enqueue(msg Message[T]) error {
+	return d.queue.Push(msg)
+}
+
+// Drain handles every buffered message in priority order. Handler errors
+// don't stop the drain; they are returned joined.
+func (d *PriorityDispatcher[T]) Drain() error {
+	var errs []error
+	for {
+		msg, ok := d.queue.Pop()
+		if !ok {
+			return errors.Join(errs...)
+		}
+		if err := d.handle(msg); err != nil {
+			errs = append(errs, fmt.Errorf("correlation %q: %w", msg.Head.Correlationid, err))
+		}
+	}
+}
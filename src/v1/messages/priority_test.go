@@ -0,0 +1,101 @@
+package messages
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func prioritized(id string, priority int) Message[string] {
+	return Message[string]{Head: Head{Correlationid: id, Priority: priority}}
+}
+
+func drainIDs(q *PriorityQueue[string]) []string {
+	var ids []string
+	for {
+		m, ok := q.Pop()
+		if !ok {
+			return ids
+		}
+		ids = append(ids, m.Head.Correlationid)
+	}
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	q := NewPriorityQueue[string](10, 0)
+	for _, m := range []Message[string]{
+		prioritized("low-1", 0),
+		prioritized("high", 5),
+		prioritized("low-2", 0),
+		prioritized("mid", 2),
+		prioritized("urgent", 9),
+	} {
+		if err := q.Push(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if q.Len() != 5 {
+		t.Errorf("Len = %d", q.Len())
+	}
+	want := []string{"urgent", "high", "mid", "low-1", "low-2"}
+	if got := drainIDs(q); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueueAging(t *testing.T) {
+	c := useFakeClock(t)
+	q := NewPriorityQueue[string](10, time.Second)
+
+	q.Push(prioritized("old-low", 0))
+	c.Advance(3 * time.Second)
+	// old-low has aged three levels and now outranks a fresh priority 2.
+	q.Push(prioritized("fresh-mid", 2))
+	q.Push(prioritized("fresh-high", 5))
+	c.Advance(time.Second)
+	q.Push(prioritized("newer-mid", 3))
+
+	want := []string{"fresh-high", "old-low", "fresh-mid", "newer-mid"}
+	if got := drainIDs(q); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueueBounded(t *testing.T) {
+	q := NewPriorityQueue[string](2, 0)
+	q.Push(prioritized("a", 0))
+	q.Push(prioritized("b", 0))
+	if err := q.Push(prioritized("c", 9)); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Push to full queue = %v, want ErrQueueFull", err)
+	}
+	q.Pop()
+	if err := q.Push(prioritized("c", 9)); err != nil {
+		t.Errorf("Push after Pop = %v", err)
+	}
+}
+
+func TestPriorityDispatcher(t *testing.T) {
+	var handled []string
+	d := NewPriorityDispatcher[string](10, 0, func(m Message[string]) error {
+		handled = append(handled, m.Head.Correlationid)
+		if m.Head.Correlationid == "bad" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	d.Enqueue(prioritized("normal", 0))
+	d.Enqueue(prioritized("bad", 1))
+	d.Enqueue(prioritized("urgent", 5))
+
+	err := d.Drain()
+	if err == nil || err.Error() != `correlation "bad": boom` {
+		t.Errorf("Drain = %v", err)
+	}
+	if want := []string{"urgent", "bad", "normal"}; !reflect.DeepEqual(handled, want) {
+		t.Errorf("handled = %v, want %v", handled, want)
+	}
+	if err := d.Drain(); err != nil {
+		t.Errorf("empty Drain = %v", err)
+	}
+}
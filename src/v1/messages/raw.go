@@ -0,0 +1,50 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// WrapRaw builds an envelope around an already-serialized body without
+// re-encoding it, so the body bytes are relayed unchanged.
+func WrapRaw(head Head, raw json.RawMessage) []byte {
+	h, _ := json.Marshal(head)
+	if len(raw) == 0 {
+		raw = json.RawMessage("null")
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(h) + len(raw) + len(`{"head":,"body":}`))
+	buf.WriteString(`{"head":`)
+	buf.Write(h)
+	buf.WriteString(`,"body":`)
+	buf.Write(raw)
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+// UnwrapRaw decodes the head and returns the body bytes untouched.
+func UnwrapRaw(data []byte) (Head, json.RawMessage, error) {
+	var m Message[json.RawMessage]
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Head{}, nil, fmt.Errorf("failed to unmarshal raw message: %w", err)
+	}
+	return m.Head, m.Body, nil
+}
+
+// SplitEnvelope separates the head from the untouched body bytes, so a
+// gateway can inspect or rewrite the head without re-parsing the body.
+func SplitEnvelope(data []byte) (head Head, bodyRaw json.RawMessage, err error) {
+	return UnwrapRaw(data)
+}
+
+// JoinEnvelope is the inverse of SplitEnvelope. Unlike WrapRaw it rejects a
+// body that isn't valid JSON.
+func JoinEnvelope(head Head, bodyRaw json.RawMessage) ([]byte, error) {
+	if len(bodyRaw) > 0 && !json.Valid(bodyRaw) {
+		return nil, errors.New("message body is not valid JSON")
+	}
+	return WrapRaw(head, bodyRaw), nil
+}
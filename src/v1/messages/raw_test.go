@@ -0,0 +1,86 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// rawBody uses key order, spacing and a number that re-encoding would change.
+var rawBody = json.RawMessage(`{ "z": 1, "a": [1.50, 2], "id": 12345678901234567890 }`)
+
+func TestWrapRawRelaysPayloadUnchanged(t *testing.T) {
+	head := Head{Destination: "out", Eventtype: "relay", Correlationid: "c-1"}
+
+	data := WrapRaw(head, rawBody)
+	if !json.Valid(data) {
+		t.Fatalf("WrapRaw produced invalid JSON: %s", data)
+	}
+
+	gotHead, gotBody, err := UnwrapRaw(data)
+	if err != nil {
+		t.Fatalf("UnwrapRaw: %v", err)
+	}
+	if gotHead.Destination != "out" || gotHead.Eventtype != "relay" || gotHead.Correlationid != "c-1" {
+		t.Errorf("head = %+v", gotHead)
+	}
+	if !bytes.Equal(gotBody, rawBody) {
+		t.Errorf("body = %s, want %s", gotBody, rawBody)
+	}
+
+	// Relaying again is byte-stable.
+	if again := WrapRaw(gotHead, gotBody); !bytes.Equal(again, data) {
+		t.Errorf("second relay = %s, want %s", again, data)
+	}
+}
+
+func TestWrapRawEmptyBody(t *testing.T) {
+	_, body, err := UnwrapRaw(WrapRaw(Head{Eventtype: "e"}, nil))
+	if err != nil {
+		t.Fatalf("UnwrapRaw: %v", err)
+	}
+	if string(body) != "null" {
+		t.Errorf("body = %s, want null", body)
+	}
+}
+
+func TestUnwrapRawInvalid(t *testing.T) {
+	if _, _, err := UnwrapRaw([]byte(`{"head":{},"body":`)); err == nil {
+		t.Error("UnwrapRaw accepted truncated JSON")
+	}
+}
+
+func TestSplitJoinEnvelopeRewritesHead(t *testing.T) {
+	in := []byte(`{"head":{"destination":"gateway","event_type":"order.placed","correlation_id":"c-1"},"body":` + string(rawBody) + `}`)
+
+	head, body, err := SplitEnvelope(in)
+	if err != nil {
+		t.Fatalf("SplitEnvelope: %v", err)
+	}
+	head.Destination = "orders"
+	out, err := JoinEnvelope(head, body)
+	if err != nil {
+		t.Fatalf("JoinEnvelope: %v", err)
+	}
+
+	gotHead, gotBody, err := SplitEnvelope(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotHead.Destination != "orders" || gotHead.Correlationid != "c-1" {
+		t.Errorf("head = %+v", gotHead)
+	}
+	if !bytes.Equal(gotBody, rawBody) || !bytes.Contains(out, rawBody) {
+		t.Errorf("body bytes changed: %s", gotBody)
+	}
+}
+
+func TestJoinEnvelopeRejectsInvalidBody(t *testing.T) {
+	if _, err := JoinEnvelope(Head{}, json.RawMessage(`{"a":`)); err == nil {
+		t.Error("JoinEnvelope accepted invalid JSON")
+	}
+	out, err := JoinEnvelope(Head{Eventtype: "e"}, nil)
+	if err != nil || !bytes.HasSuffix(out, []byte(`"body":null}`)) {
+		t.Errorf("JoinEnvelope(nil body) = %s, %v", out, err)
+	}
+}
@@ -0,0 +1,82 @@
+package messages
+
+import (
+	"sync"
+	"time"
+)
+
+// Reassembler buffers out-of-order parts of streamed messages, keyed by
+// correlation ID, and releases them in Head.Sequence order starting at 0.
+// A stream completes when its Final part has been released.
+type Reassembler[T any] struct {
+	mu      sync.Mutex
+	timeout time.Duration
+	streams map[string]*partStream[T]
+}
+
+type partStream[T any] struct {
+	next    int
+	pending map[int]Message[T]
+	updated time.Time
+}
+
+// NewReassembler creates a reassembler whose streams expire after timeout
+// without progress.
+func NewReassembler[T any](timeout time.Duration) *Reassembler[T] {
+	return &Reassembler[T]{
+		timeout: timeout,
+		streams: make(map[string]*partStream[T]),
+	}
+}
+
+// Add buffers msg and returns the parts that are now ready, in order. done
+// reports that the final part was released; the stream is then forgotten.
+// Duplicate or already released parts are ignored.
+func (r *Reassembler[T]) Add(msg Message[T]) (ready []Message[T], done bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := msg.Head.Correlationid
+	s, ok := r.streams[id]
+	if !ok {
+		s = &partStream[T]{pending: make(map[int]Message[T])}
+		r.streams[id] = s
+	}
+	if msg.Head.Sequence < s.next {
+		return nil, false
+	}
+	s.pending[msg.Head.Sequence] = msg
+	s.updated = now()
+
+	for {
+		part, ok := s.pending[s.next]
+		if !ok {
+			break
+		}
+		delete(s.pending, s.next)
+		s.next++
+		ready = append(ready, part)
+		if part.Head.Final {
+			delete(r.streams, id)
+			return ready, true
+		}
+	}
+	return ready, false
+}
+
+// Expire drops streams that made no progress within the timeout, e.g.
+// because a part never arrived, and returns their correlation IDs.
+func (r *Reassembler[T]) Expire() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := now().Add(-r.timeout)
+	var expired []string
+	for id, s := range r.streams {
+		if s.updated.Before(cutoff) {
+			delete(r.streams, id)
+			expired = append(expired, id)
+		}
+	}
+	return expired
+}
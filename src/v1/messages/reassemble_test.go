@@ -0,0 +1,92 @@
+package messages
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func part(id string, seq int, final bool) Message[string] {
+	return Message[string]{
+		Head: Head{Correlationid: id, Sequence: seq, Final: final},
+		Body: id + "-" + string(rune('a'+seq)),
+	}
+}
+
+func bodies(parts []Message[string]) []string {
+	out := make([]string, len(parts))
+	for i, p := range parts {
+		out[i] = p.Body
+	}
+	return out
+}
+
+func TestReassemblerInOrder(t *testing.T) {
+	r := NewReassembler[string](time.Minute)
+	for seq := 0; seq < 2; seq++ {
+		ready, done := r.Add(part("c", seq, false))
+		if done || !reflect.DeepEqual(bodies(ready), []string{part("c", seq, false).Body}) {
+			t.Errorf("part %d: ready = %v, done = %v", seq, bodies(ready), done)
+		}
+	}
+	ready, done := r.Add(part("c", 2, true))
+	if !done || !reflect.DeepEqual(bodies(ready), []string{"c-c"}) {
+		t.Errorf("final: ready = %v, done = %v", bodies(ready), done)
+	}
+}
+
+func TestReassemblerOutOfOrder(t *testing.T) {
+	r := NewReassembler[string](time.Minute)
+
+	if ready, done := r.Add(part("c", 2, true)); len(ready) != 0 || done {
+		t.Errorf("final first: ready = %v, done = %v", bodies(ready), done)
+	}
+	if ready, _ := r.Add(part("c", 1, false)); len(ready) != 0 {
+		t.Errorf("gap at 0: ready = %v", bodies(ready))
+	}
+	// Interleaved streams don't affect each other.
+	if ready, _ := r.Add(part("other", 0, false)); !reflect.DeepEqual(bodies(ready), []string{"other-a"}) {
+		t.Errorf("other stream: ready = %v", bodies(ready))
+	}
+	ready, done := r.Add(part("c", 0, false))
+	if !done || !reflect.DeepEqual(bodies(ready), []string{"c-a", "c-b", "c-c"}) {
+		t.Errorf("gap filled: ready = %v, done = %v", bodies(ready), done)
+	}
+}
+
+func TestReassemblerIgnoresDuplicates(t *testing.T) {
+	r := NewReassembler[string](time.Minute)
+	r.Add(part("c", 0, false))
+	if ready, _ := r.Add(part("c", 0, false)); len(ready) != 0 {
+		t.Errorf("released part re-released: %v", bodies(ready))
+	}
+	r.Add(part("c", 2, true))
+	r.Add(part("c", 2, true))
+	ready, done := r.Add(part("c", 1, false))
+	if !done || !reflect.DeepEqual(bodies(ready), []string{"c-b", "c-c"}) {
+		t.Errorf("ready = %v, done = %v", bodies(ready), done)
+	}
+}
+
+func TestReassemblerExpiresMissingPart(t *testing.T) {
+	c := useFakeClock(t)
+	r := NewReassembler[string](30 * time.Second)
+
+	r.Add(part("stuck", 0, false))
+	r.Add(part("stuck", 2, true)) // part 1 never arrives
+	c.Advance(20 * time.Second)
+	r.Add(part("live", 0, false))
+
+	if expired := r.Expire(); len(expired) != 0 {
+		t.Errorf("expired before the timeout: %v", expired)
+	}
+	c.Advance(15 * time.Second)
+	if expired := r.Expire(); !reflect.DeepEqual(expired, []string{"stuck"}) {
+		t.Errorf("expired = %v, want [stuck]", expired)
+	}
+
+	// The late part starts a fresh stream rather than completing the old one.
+	if ready, done := r.Add(part("stuck", 1, false)); len(ready) != 0 || done {
+		t.Errorf("late part: ready = %v, done = %v", bodies(ready), done)
+	}
+}
@@ -0,0 +1,185 @@
+package messages
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+const (
+	// redactedPlaceholder replaces fields tagged log:"redact".
+	redactedPlaceholder = "[redacted]"
+	// cyclePlaceholder replaces pointers and maps that refer back to one of
+	// their ancestors.
+	cyclePlaceholder = "[cycle]"
+	// tooDeepPlaceholder replaces values nested deeper than maxRedactDepth.
+	tooDeepPlaceholder = "[too deep]"
+)
+
+// maxRedactDepth bounds how deep RedactForLog descends into a body.
+const maxRedactDepth = 64
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// RedactForLog returns a view of body with struct fields tagged
+// `log:"redact"` replaced by "[redacted]", for logging message bodies:
+//
+//	type Patient struct {
+//		Name string `json:"name"`
+//		SSN  string `json:"ssn" log:"redact"`
+//	}
+//
+// Structs containing redacted fields, directly or nested in pointers,
+// slices and maps, are returned as maps keyed by their JSON names so they
+// encode like the original. Values without redacted fields, and types with
+// their own JSON or text marshaling, are returned unchanged.
+func RedactForLog(body any) any {
+	out, _ := redactForLog(body)
+	return out
+}
+
+// redactForLog is RedactForLog, also reporting whether anything was masked.
+func redactForLog(body any) (any, bool) {
+	if body == nil {
+		return nil, false
+	}
+	w := redactWalk{visiting: make(map[uintptr]bool)}
+	out, changed := w.value(reflect.ValueOf(body))
+	if !changed {
+		return body, false
+	}
+	return out, true
+}
+
+// redactWalk tracks the pointers and maps on the current path, so cyclic
+// bodies end in a placeholder instead of overflowing the stack.
+type redactWalk struct {
+	visiting map[uintptr]bool
+	depth    int
+}
+
+// value returns the redacted view of v and whether it differs from v.
+func (w *redactWalk) value(v reflect.Value) (any, bool) {
+	if t := v.Type(); t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return v.Interface(), false
+	}
+	if w.depth >= maxRedactDepth {
+		return tooDeepPlaceholder, true
+	}
+	w.depth++
+	defer func() { w.depth-- }()
+
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Map) && !v.IsNil() {
+		ptr := v.Pointer()
+		if w.visiting[ptr] {
+			return cyclePlaceholder, true
+		}
+		w.visiting[ptr] = true
+		defer delete(w.visiting, ptr)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return v.Interface(), false
+		}
+		if out, changed := w.value(v.Elem()); changed {
+			return out, true
+		}
+	case reflect.Struct:
+		if out, changed := w.structFields(v); changed {
+			return out, true
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		out := make([]any, v.Len())
+		changed := false
+		for i := range out {
+			var c bool
+			out[i], c = w.value(v.Index(i))
+			changed = changed || c
+		}
+		if changed {
+			return out, true
+		}
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		out := make(map[string]any, v.Len())
+		changed := false
+		iter := v.MapRange()
+		for iter.Next() {
+			var c bool
+			out[iter.Key().String()], c = w.value(iter.Value())
+			changed = changed || c
+		}
+		if changed {
+			return out, true
+		}
+	}
+	return v.Interface(), false
+}
+
+// structFields renders the exported fields of v as a map keyed by JSON
+// name, flattening embedded structs the way encoding/json does.
+func (w *redactWalk) structFields(v reflect.Value) (map[string]any, bool) {
+	out := make(map[string]any, v.NumField())
+	changed := false
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		// Like encoding/json, promote the fields of unexported embedded
+		// structs but skip other unexported fields.
+		if !f.IsExported() && !(f.Anonymous && f.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+
+		if f.Anonymous && name == "" {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct && !fv.Type().Implements(jsonMarshalerType) {
+				sub, c := w.structFields(fv)
+				for k, sv := range sub {
+					if _, ok := out[k]; !ok {
+						out[k] = sv
+					}
+				}
+				changed = changed || c
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if f.Tag.Get("log") == "redact" {
+			out[name] = redactedPlaceholder
+			changed = true
+			continue
+		}
+		var c bool
+		out[name], c = w.value(fv)
+		changed = changed || c
+	}
+	return out, changed
+}
@@ -0,0 +1,106 @@
+package messages
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type redactPatient struct {
+	Name string `json:"name"`
+	SSN  string `json:"ssn" log:"redact"`
+}
+
+type redactAudit struct {
+	At time.Time `json:"at"`
+}
+
+type redactVisit struct {
+	redactAudit
+	Patient  *redactPatient           `json:"patient"`
+	Contacts []redactPatient          `json:"contacts"`
+	ByRoom   map[string]redactPatient `json:"by_room"`
+	Note     string                   `json:"-"`
+}
+
+func encode(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(b)
+}
+
+func TestRedactForLog(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	body := redactVisit{
+		redactAudit: redactAudit{At: at},
+		Patient:     &redactPatient{Name: "Ann", SSN: "123-45-6789"},
+		Contacts:    []redactPatient{{Name: "Bob", SSN: "987-65-4321"}},
+		ByRoom:      map[string]redactPatient{"7": {Name: "Cy", SSN: "555-55-5555"}},
+		Note:        "hidden",
+	}
+
+	got := encode(t, RedactForLog(body))
+	want := `{"at":"2024-05-01T12:00:00Z",` +
+		`"by_room":{"7":{"name":"Cy","ssn":"[redacted]"}},` +
+		`"contacts":[{"name":"Bob","ssn":"[redacted]"}],` +
+		`"patient":{"name":"Ann","ssn":"[redacted]"}}`
+	if got != want {
+		t.Errorf("RedactForLog = %s\nwant %s", got, want)
+	}
+}
+
+func TestRedactForLogUnchanged(t *testing.T) {
+	type plain struct {
+		A int    `json:"a"`
+		B []byte `json:"b"`
+	}
+	for _, body := range []any{nil, 42, "x", plain{A: 1, B: []byte("y")}, time.Now()} {
+		out, changed := redactForLog(body)
+		if changed {
+			t.Errorf("redactForLog(%#v) reported a change", body)
+		}
+		if !reflect.DeepEqual(out, body) {
+			t.Errorf("redactForLog(%#v) = %#v, want the input back", body, out)
+		}
+	}
+}
+
+type redactNode struct {
+	Name string      `json:"name"`
+	Next *redactNode `json:"next"`
+}
+
+func TestRedactForLogCycle(t *testing.T) {
+	n := &redactNode{Name: "a"}
+	n.Next = &redactNode{Name: "b", Next: n}
+
+	got := encode(t, RedactForLog(n))
+	if !strings.Contains(got, `"next":"[cycle]"`) {
+		t.Errorf("RedactForLog = %s, want the back reference replaced", got)
+	}
+
+	m := map[string]any{"k": "v"}
+	m["self"] = m
+	if got := encode(t, RedactForLog(m)); got != `{"k":"v","self":"[cycle]"}` {
+		t.Errorf("RedactForLog(map) = %s", got)
+	}
+}
+
+func TestRedactForLogDepth(t *testing.T) {
+	var body any = redactPatient{Name: "leaf", SSN: "secret"}
+	for i := 0; i < maxRedactDepth+10; i++ {
+		body = []any{body}
+	}
+	got := encode(t, RedactForLog(body))
+	if strings.Contains(got, "secret") {
+		t.Error("deeply nested redacted field leaked")
+	}
+	if !strings.Contains(got, tooDeepPlaceholder) {
+		t.Errorf("RedactForLog = %s, want %q", got, tooDeepPlaceholder)
+	}
+}
@@ -0,0 +1,56 @@
+package messages
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Replay streams an NDJSON archive of messages from r, rewrites each head
+// with transform (when non-nil) and passes the result to emit. Malformed
+// lines and emit failures are collected per line and returned joined once
+// the whole input has been processed.
+func Replay(r io.Reader, transform func(Head) Head, emit func(Message[any]) error) error {
+	br := bufio.NewReader(r)
+	var errs []error
+
+	for lineNo := 1; ; lineNo++ {
+		line, readErr := br.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			errs = append(errs, fmt.Errorf("line %d: %w", lineNo, readErr))
+			break
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if err := replayLine(line, transform, emit); err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %w", lineNo, err))
+			}
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func replayLine(line []byte, transform func(Head) Head, emit func(Message[any]) error) error {
+	// UseNumber keeps large integer IDs exact instead of rounding them
+	// through float64.
+	var m Message[any]
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil {
+		return fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+	if dec.More() {
+		return errors.New("failed to unmarshal message: unexpected data after message")
+	}
+	if transform != nil {
+		m.Head = transform(m.Head)
+	}
+	return emit(m)
+}
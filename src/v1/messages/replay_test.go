@@ -0,0 +1,55 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	input := strings.Join([]string{
+		`{"head":{"event_type":"a","destination":"old"},"body":{"client_id":1152921504606846977}}`,
+		``,
+		`not json`,
+		`{"head":{"event_type":"b"},"body":null} trailing`,
+		`{"head":{"event_type":"fail"},"body":{}}`,
+		`{"head":{"event_type":"c"},"body":"x"}`,
+	}, "\n")
+
+	var emitted []Message[any]
+	err := Replay(strings.NewReader(input),
+		func(h Head) Head {
+			h.Destination = "new"
+			return h
+		},
+		func(m Message[any]) error {
+			if m.Head.Eventtype == "fail" {
+				return errors.New("emit failed")
+			}
+			emitted = append(emitted, m)
+			return nil
+		})
+
+	if len(emitted) != 2 {
+		t.Fatalf("emitted %d messages, want 2", len(emitted))
+	}
+	if emitted[0].Head.Destination != "new" {
+		t.Errorf("transform not applied: %+v", emitted[0].Head)
+	}
+
+	out, _ := json.Marshal(emitted[0].Body)
+	if !bytes.Contains(out, []byte(`"client_id":1152921504606846977`)) {
+		t.Errorf("large ID changed on replay: %s", out)
+	}
+
+	if err == nil {
+		t.Fatal("expected errors for malformed and failed lines")
+	}
+	for _, want := range []string{"line 3:", "line 4:", "line 5: emit failed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package messages
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ReplayGuard remembers recently seen idempotency keys so redelivered
+// messages can be skipped. It holds at most size keys, each for ttl after it
+// was first seen; the oldest keys are evicted first. It is safe for
+// concurrent use.
+type ReplayGuard struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	order *list.List
+	keys  map[string]*list.Element
+}
+
+type seenKey struct {
+	key  string
+	seen time.Time
+}
+
+// NewReplayGuard creates a guard holding up to size keys for ttl each.
+func NewReplayGuard(size int, ttl time.Duration) *ReplayGuard {
+	return &ReplayGuard{
+		size:  size,
+		ttl:   ttl,
+		order: list.New(),
+		keys:  make(map[string]*list.Element),
+	}
+}
+
+// Seen reports whether key was already seen within the TTL, recording it
+// if not.
+func (g *ReplayGuard) Seen(key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	t := now()
+	g.expire(t)
+	if _, ok := g.keys[key]; ok {
+		return true
+	}
+
+	g.keys[key] = g.order.PushFront(seenKey{key: key, seen: t})
+	for g.order.Len() > g.size {
+		g.remove(g.order.Back())
+	}
+	return false
+}
+
+// Len returns the number of keys currently remembered.
+func (g *ReplayGuard) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.expire(now())
+	return g.order.Len()
+}
+
+// expire drops keys older than the TTL; they sit at the back of the list.
+func (g *ReplayGuard) expire(t time.Time) {
+	for el := g.order.Back(); el != nil; el = g.order.Back() {
+		if t.Sub(el.Value.(seenKey).seen) < g.ttl {
+			return
+		}
+		g.remove(el)
+	}
+}
+
+func (g *ReplayGuard) remove(el *list.Element) {
+	delete(g.keys, el.Value.(seenKey).key)
+	g.order.Remove(el)
+}
@@ -0,0 +1,94 @@
+package messages
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReplayGuardDetectsDuplicates(t *testing.T) {
+	useFakeClock(t)
+	g := NewReplayGuard(10, time.Minute)
+	if g.Seen("a") {
+		t.Error("first delivery reported as seen")
+	}
+	if !g.Seen("a") {
+		t.Error("redelivery not detected")
+	}
+	if g.Seen("b") {
+		t.Error("different key reported as seen")
+	}
+	if g.Len() != 2 {
+		t.Errorf("Len = %d, want 2", g.Len())
+	}
+}
+
+func TestReplayGuardEvictsBySize(t *testing.T) {
+	useFakeClock(t)
+	g := NewReplayGuard(3, time.Hour)
+	for _, k := range []string{"a", "b", "c", "d"} {
+		g.Seen(k)
+	}
+	if g.Len() != 3 {
+		t.Errorf("Len = %d, want 3", g.Len())
+	}
+	// "a" was the oldest and got evicted; the rest are remembered.
+	for _, k := range []string{"b", "c", "d"} {
+		if !g.Seen(k) {
+			t.Errorf("%q forgotten", k)
+		}
+	}
+	if g.Seen("a") {
+		t.Error("evicted key still reported as seen")
+	}
+}
+
+func TestReplayGuardEvictsByTTL(t *testing.T) {
+	c := useFakeClock(t)
+	g := NewReplayGuard(10, time.Minute)
+
+	g.Seen("old")
+	c.Advance(40 * time.Second)
+	g.Seen("new")
+	c.Advance(20 * time.Second)
+
+	if g.Len() != 1 {
+		t.Errorf("Len = %d, want only the unexpired key", g.Len())
+	}
+	if g.Seen("old") {
+		t.Error("expired key reported as seen")
+	}
+	if !g.Seen("new") {
+		t.Error("unexpired key forgotten")
+	}
+	// A duplicate doesn't refresh the key's TTL.
+	c.Advance(40 * time.Second)
+	if g.Seen("new") {
+		t.Error("key outlived its TTL")
+	}
+}
+
+func TestReplayGuardConcurrent(t *testing.T) {
+	g := NewReplayGuard(1000, time.Hour)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	firsts := 0
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if !g.Seen(fmt.Sprint(i)) {
+					mu.Lock()
+					firsts++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firsts != 100 {
+		t.Errorf("%d keys reported new, want 100", firsts)
+	}
+}
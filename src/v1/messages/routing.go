@@ -0,0 +1,81 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownRoute is returned when a head's source or destination is not in
+// the validator's allowlist.
+var ErrUnknownRoute = errors.New("unknown route")
+
+// RoutingValidator checks Head.Source and Head.Destination against sets of
+// known services. An empty set allows any non-empty value.
+type RoutingValidator struct {
+	sources      map[string]struct{}
+	destinations map[string]struct{}
+}
+
+// NewRoutingValidator creates a validator with the allowed sources and
+// destinations.
+func NewRoutingValidator(sources, destinations []string) *RoutingValidator {
+	return &RoutingValidator{
+		sources:      toSet(sources),
+		destinations: toSet(destinations),
+	}
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// ValidateRouting rejects empty or unknown sources and destinations.
+func (v *RoutingValidator) ValidateRouting(h Head) error {
+	if h.Source == "" {
+		return fmt.Errorf("%w: missing source", ErrInvalidMessage)
+	}
+	if h.Destination == "" {
+		return fmt.Errorf("%w: missing destination", ErrInvalidMessage)
+	}
+	if _, ok := v.sources[h.Source]; len(v.sources) > 0 && !ok {
+		return fmt.Errorf("%w: source %q", ErrUnknownRoute, h.Source)
+	}
+	if _, ok := v.destinations[h.Destination]; len(v.destinations) > 0 && !ok {
+		return fmt.Errorf("%w: destination %q", ErrUnknownRoute, h.Destination)
+	}
+	return nil
+}
+
+// Convert validates the routing of raw before unmarshalling it into out, so
+// misrouted messages are rejected before the body is decoded.
+func (v *RoutingValidator) Convert(raw []byte, out interface{}) error {
+	var m Message[json.RawMessage]
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return fmt.Errorf("failed to unmarshal message head: %w", err)
+	}
+	if err := v.ValidateRouting(m.Head); err != nil {
+		return err
+	}
+	return Convert(raw, out)
+}
+
+// SetRoutingKey sets the broker routing key (queue or topic). Destination
+// stays the logical service name; the routing key is where a publisher
+// actually sends the message.
+func (h *Head) SetRoutingKey(key string) {
+	h.RoutingKey = key
+}
+
+// Route returns the routing key if set, otherwise the destination, for
+// publishers that map destinations directly to queues.
+func (h Head) Route() string {
+	if h.RoutingKey != "" {
+		return h.RoutingKey
+	}
+	return h.Destination
+}
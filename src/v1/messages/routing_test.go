@@ -0,0 +1,94 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateRouting(t *testing.T) {
+	v := NewRoutingValidator([]string{"api", "worker"}, []string{"billing"})
+
+	tests := []struct {
+		name string
+		head Head
+		want error
+	}{
+		{"allowed", Head{Source: "worker", Destination: "billing"}, nil},
+		{"unknown source", Head{Source: "rogue", Destination: "billing"}, ErrUnknownRoute},
+		{"unknown destination", Head{Source: "api", Destination: "shipping"}, ErrUnknownRoute},
+		{"empty source", Head{Destination: "billing"}, ErrInvalidMessage},
+		{"empty destination", Head{Source: "api"}, ErrInvalidMessage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateRouting(tt.head)
+			if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("ValidateRouting = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRoutingEmptySetAllowsAny(t *testing.T) {
+	v := NewRoutingValidator(nil, []string{"billing"})
+	if err := v.ValidateRouting(Head{Source: "anything", Destination: "billing"}); err != nil {
+		t.Errorf("ValidateRouting = %v", err)
+	}
+	if err := v.ValidateRouting(Head{Source: "", Destination: "billing"}); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("empty source with open set = %v, want ErrInvalidMessage", err)
+	}
+}
+
+func TestRoutingValidatorConvert(t *testing.T) {
+	v := NewRoutingValidator([]string{"api"}, []string{"billing"})
+
+	var m IncomingMessage
+	ok := []byte(`{"head":{"source":"api","destination":"billing","event_type":"e"},"body":{"message":"hi"}}`)
+	if err := v.Convert(ok, &m); err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if m.Body.Message != "hi" {
+		t.Errorf("body = %+v", m.Body)
+	}
+
+	var rejected IncomingMessage
+	bad := []byte(`{"head":{"source":"rogue","destination":"billing"},"body":{"message":"hi"}}`)
+	if err := v.Convert(bad, &rejected); !errors.Is(err, ErrUnknownRoute) {
+		t.Errorf("Convert = %v, want ErrUnknownRoute", err)
+	}
+	if rejected.Body.Message != "" {
+		t.Error("body decoded for a rejected route")
+	}
+}
+
+func TestRoutingKey(t *testing.T) {
+	h := Head{Destination: "billing"}
+	if h.Route() != "billing" {
+		t.Errorf("Route without key = %q, want the destination", h.Route())
+	}
+	if b, _ := json.Marshal(h); strings.Contains(string(b), "routing_key") {
+		t.Errorf("empty routing key encoded: %s", b)
+	}
+
+	h.SetRoutingKey("billing.invoices.eu")
+	if h.Route() != "billing.invoices.eu" {
+		t.Errorf("Route = %q, want the routing key", h.Route())
+	}
+
+	b, err := json.Marshal(Message[string]{Head: h})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"destination":"billing"`) || !strings.Contains(string(b), `"routing_key":"billing.invoices.eu"`) {
+		t.Errorf("encoded head = %s", b)
+	}
+	var back Message[string]
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Head.Destination != "billing" || back.Head.RoutingKey != "billing.invoices.eu" {
+		t.Errorf("decoded head = %+v", back.Head)
+	}
+}
@@ -0,0 +1,58 @@
+package messages
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TypeSampler decides per event type whether a message should be logged,
+// keeping 1 in N messages of each type. Rates of 1 or less keep everything.
+type TypeSampler struct {
+	// Default is the rate for event types without an override.
+	Default int
+	// Rates overrides the rate per event type, e.g. {"heartbeat": 100}.
+	Rates map[string]int
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+// Sample reports whether the next message of eventType should be logged.
+// The first message of each type is always kept.
+func (s *TypeSampler) Sample(eventType string) bool {
+	rate, ok := s.Rates[eventType]
+	if !ok {
+		rate = s.Default
+	}
+	if rate <= 1 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts == nil {
+		s.counts = make(map[string]uint64)
+	}
+	n := s.counts[eventType]
+	s.counts[eventType] = n + 1
+	return n%uint64(rate) == 0
+}
+
+// TimedHandle is like the package-level TimedHandle, but successful runs
+// are only logged when the sampler keeps them. Failures are always logged.
+func (s *TypeSampler) TimedHandle(l *logrus.Logger, eventType string, fn func() error) error {
+	if s.Sample(eventType) {
+		return TimedHandle(l, eventType, fn)
+	}
+
+	start := now()
+	err := fn()
+	if err != nil {
+		l.WithFields(logrus.Fields{
+			"event_type":  eventType,
+			"duration_ms": now().Sub(start).Milliseconds(),
+		}).WithError(err).Error("message handling failed")
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package messages
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestTypeSamplerRates(t *testing.T) {
+	s := &TypeSampler{Default: 1, Rates: map[string]int{"heartbeat": 100, "status": 10, "audit": 0}}
+
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		for _, et := range []string{"heartbeat", "status", "audit", "order.placed"} {
+			if s.Sample(et) {
+				counts[et]++
+			}
+		}
+	}
+	want := map[string]int{"heartbeat": 10, "status": 100, "audit": 1000, "order.placed": 1000}
+	for et, n := range want {
+		if counts[et] != n {
+			t.Errorf("%s kept %d of 1000, want %d", et, counts[et], n)
+		}
+	}
+}
+
+func TestTypeSamplerDefaultRate(t *testing.T) {
+	s := &TypeSampler{Default: 3, Rates: map[string]int{"critical": 1}}
+
+	var got []bool
+	for i := 0; i < 6; i++ {
+		got = append(got, s.Sample("other"))
+	}
+	// The first message of a type is kept, then every third.
+	if want := []bool{true, false, false, true, false, false}; !slices.Equal(got, want) {
+		t.Errorf("default rate samples = %v, want %v", got, want)
+	}
+	for i := 0; i < 5; i++ {
+		if !s.Sample("critical") {
+			t.Fatal("override of 1 dropped a message")
+		}
+	}
+}
+
+func TestTypeSamplerConcurrent(t *testing.T) {
+	s := &TypeSampler{Default: 10}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		kept int
+	)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 125; i++ {
+				if s.Sample("e") {
+					mu.Lock()
+					kept++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if kept != 100 {
+		t.Errorf("kept %d of 1000 at 1 in 10", kept)
+	}
+}
+
+func TestTypeSamplerTimedHandle(t *testing.T) {
+	useFakeClock(t)
+	l, hook := test.NewNullLogger()
+	s := &TypeSampler{Rates: map[string]int{"heartbeat": 100}}
+
+	for i := 0; i < 100; i++ {
+		_ = s.TimedHandle(l, "heartbeat", func() error { return nil })
+	}
+	if n := len(hook.AllEntries()); n != 1 {
+		t.Errorf("%d heartbeats logged, want 1", n)
+	}
+
+	// Failures are logged even when the sampler would drop the message.
+	hook.Reset()
+	boom := errors.New("boom")
+	if err := s.TimedHandle(l, "heartbeat", func() error { return boom }); err != boom {
+		t.Fatalf("TimedHandle = %v", err)
+	}
+	e := hook.LastEntry()
+	if e == nil || e.Level != logrus.ErrorLevel || e.Data["event_type"] != "heartbeat" {
+		t.Errorf("failure entry = %+v", e)
+	}
+}
@@ -0,0 +1,18 @@
+package messages
+
+// sensitivePlaceholder replaces the body of sensitive messages in logs.
+const sensitivePlaceholder = "[sensitive]"
+
+// Loggable returns a view of the message that is safe to log. When the head
+// is marked Sensitive the body is replaced with "[sensitive]"; otherwise
+// body fields tagged log:"redact" are masked (see RedactForLog). The head is
+// kept as is.
+func (m Message[T]) Loggable() any {
+	if !m.Head.Sensitive {
+		if body, changed := redactForLog(m.Body); changed {
+			return Message[any]{Head: m.Head, Body: body}
+		}
+		return m
+	}
+	return Message[string]{Head: m.Head, Body: sensitivePlaceholder}
+}
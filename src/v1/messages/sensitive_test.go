@@ -0,0 +1,37 @@
+package messages
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoggableMasksSensitiveBody(t *testing.T) {
+	m := Message[IncomingMessageBody]{
+		Head: Head{Eventtype: "patient.updated", Correlationid: "c-1", Sensitive: true},
+		Body: IncomingMessageBody{ClientID: 1, Message: "diagnosis"},
+	}
+
+	got, ok := m.Loggable().(Message[string])
+	if !ok {
+		t.Fatalf("Loggable = %T, want Message[string]", m.Loggable())
+	}
+	if got.Body != "[sensitive]" {
+		t.Errorf("body = %q", got.Body)
+	}
+	if !reflect.DeepEqual(got.Head, m.Head) {
+		t.Errorf("head = %+v, want %+v", got.Head, m.Head)
+	}
+	if m.Body.Message != "diagnosis" {
+		t.Error("Loggable modified the original message")
+	}
+}
+
+func TestLoggableKeepsOrdinaryMessage(t *testing.T) {
+	m := IncomingMessage{Message[IncomingMessageBody]{
+		Head: Head{Eventtype: "chat.message"},
+		Body: IncomingMessageBody{Message: "hello"},
+	}}
+	if got := m.Loggable(); !reflect.DeepEqual(got, m.Message) {
+		t.Errorf("Loggable = %+v, want the message unchanged", got)
+	}
+}
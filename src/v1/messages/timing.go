@@ -0,0 +1,22 @@
+package messages
+
+import "github.com/sirupsen/logrus"
+
+// TimedHandle runs fn and logs how long it took with event_type and
+// duration_ms fields: at Info on success, at Error (with the error) if fn
+// fails. fn's error is returned unchanged.
+func TimedHandle(l *logrus.Logger, eventType string, fn func() error) error {
+	start := now()
+	err := fn()
+
+	entry := l.WithFields(logrus.Fields{
+		"event_type":  eventType,
+		"duration_ms": now().Sub(start).Milliseconds(),
+	})
+	if err != nil {
+		entry.WithError(err).Error("message handling failed")
+		return err
+	}
+	entry.Info("message handled")
+	return nil
+}
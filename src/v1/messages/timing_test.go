@@ -0,0 +1,56 @@
+package messages
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestTimedHandle(t *testing.T) {
+	c := useFakeClock(t)
+	l, hook := test.NewNullLogger()
+
+	err := TimedHandle(l, "order.placed", func() error {
+		c.Advance(1500 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TimedHandle = %v", err)
+	}
+
+	e := hook.LastEntry()
+	if e.Level != logrus.InfoLevel || e.Data["event_type"] != "order.placed" {
+		t.Errorf("entry = %s %v", e.Level, e.Data)
+	}
+	if e.Data["duration_ms"] != int64(1500) {
+		t.Errorf("duration_ms = %#v, want 1500", e.Data["duration_ms"])
+	}
+}
+
+func TestTimedHandleError(t *testing.T) {
+	c := useFakeClock(t)
+	l, hook := test.NewNullLogger()
+	boom := errors.New("boom")
+
+	err := TimedHandle(l, "order.placed", func() error {
+		c.Advance(20 * time.Millisecond)
+		return boom
+	})
+	if err != boom {
+		t.Fatalf("TimedHandle = %v, want fn's error unchanged", err)
+	}
+
+	e := hook.LastEntry()
+	if e.Level != logrus.ErrorLevel || e.Data[logrus.ErrorKey] != boom {
+		t.Errorf("entry = %s %v", e.Level, e.Data)
+	}
+	if e.Data["duration_ms"] != int64(20) || e.Data["event_type"] != "order.placed" {
+		t.Errorf("fields = %v", e.Data)
+	}
+	if len(hook.AllEntries()) != 1 {
+		t.Errorf("%d entries logged, want 1", len(hook.AllEntries()))
+	}
+}
@@ -0,0 +1,18 @@
+package messages
+
+// AddHop appends source to the message's trace with the current time.
+func (m *Message[T]) AddHop(source string) {
+	m.Head.Trace = append(m.Head.Trace, TraceHop{
+		Source: source,
+		Time:   int(now().Unix()),
+	})
+}
+
+// Path returns the sources recorded in the trace, oldest first.
+func (m Message[T]) Path() []string {
+	path := make([]string, len(m.Head.Trace))
+	for i, hop := range m.Head.Trace {
+		path[i] = hop.Source
+	}
+	return path
+}
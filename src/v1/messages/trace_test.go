@@ -0,0 +1,48 @@
+package messages
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddHopAndPath(t *testing.T) {
+	var m Message[string]
+	if path := m.Path(); len(path) != 0 {
+		t.Errorf("empty path = %v", path)
+	}
+
+	before := time.Now().Unix()
+	m.AddHop("api")
+	m.AddHop("router")
+	m.AddHop("billing")
+
+	if path := m.Path(); !reflect.DeepEqual(path, []string{"api", "router", "billing"}) {
+		t.Errorf("Path = %v", path)
+	}
+	for _, hop := range m.Head.Trace {
+		if int64(hop.Time) < before || int64(hop.Time) > time.Now().Unix() {
+			t.Errorf("hop %q time = %d", hop.Source, hop.Time)
+		}
+	}
+}
+
+func TestTraceIsOptIn(t *testing.T) {
+	b, _ := json.Marshal(Message[string]{Head: Head{Eventtype: "e"}})
+	if strings.Contains(string(b), `"trace"`) {
+		t.Errorf("untraced message encodes a trace: %s", b)
+	}
+
+	var m Message[string]
+	m.AddHop("api")
+	b, _ = json.Marshal(m)
+	var back Message[string]
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back.Head.Trace, m.Head.Trace) {
+		t.Errorf("trace round trip = %+v, want %+v", back.Head.Trace, m.Head.Trace)
+	}
+}
@@ -0,0 +1,22 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// As converts a loosely decoded message into a typed one once its event
+// type is known, by re-encoding the generic body into T. Go methods can't
+// take type parameters, so this is a function rather than a method on
+// UnstricMessage.
+func As[T any](u UnstricMessage) (Message[T], error) {
+	raw, err := json.Marshal(u.Body)
+	if err != nil {
+		return Message[T]{}, fmt.Errorf("failed to marshal message body: %w", err)
+	}
+	m := Message[T]{Head: u.Head}
+	if err := json.Unmarshal(raw, &m.Body); err != nil {
+		return Message[T]{}, fmt.Errorf("failed to convert message body to %T: %w", m.Body, err)
+	}
+	return m, nil
+}
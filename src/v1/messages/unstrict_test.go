@@ -0,0 +1,51 @@
+package messages
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type chatBody struct {
+	Text string `json:"text"`
+	Room int    `json:"room"`
+}
+
+func TestAsConvertsBody(t *testing.T) {
+	var u UnstricMessage
+	raw := `{"head":{"event_type":"chat.message","correlation_id":"c-1"},"body":{"text":"hi","room":7,"extra":true}}`
+	if err := json.Unmarshal([]byte(raw), &u); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := As[chatBody](u)
+	if err != nil {
+		t.Fatalf("As: %v", err)
+	}
+	if m.Head.Eventtype != "chat.message" || m.Head.Correlationid != "c-1" {
+		t.Errorf("head = %+v", m.Head)
+	}
+	if m.Body != (chatBody{Text: "hi", Room: 7}) {
+		t.Errorf("body = %+v", m.Body)
+	}
+
+	if s, err := As[map[string]any](u); err != nil || s.Body["text"] != "hi" {
+		t.Errorf("As[map] = %+v, %v", s.Body, err)
+	}
+}
+
+func TestAsFailsOnMismatchedBody(t *testing.T) {
+	u := UnstricMessage{Message[any]{Head: Head{Eventtype: "e"}, Body: []any{"not", "an", "object"}}}
+	if _, err := As[chatBody](u); err == nil {
+		t.Error("As converted an array into a struct")
+	}
+
+	u.Body = map[string]any{"room": "seven"}
+	if _, err := As[chatBody](u); err == nil {
+		t.Error("As converted a string field into an int")
+	}
+
+	u.Body = make(chan int)
+	if _, err := As[chatBody](u); err == nil {
+		t.Error("As accepted an unencodable body")
+	}
+}
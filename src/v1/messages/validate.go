@@ -0,0 +1,44 @@
+package messages
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidMessage is returned when a message is missing a required head
+// field or its body fails validation.
+var ErrInvalidMessage = errors.New("invalid message")
+
+// Validator is implemented by message bodies that can check themselves.
+type Validator interface {
+	Validate() error
+}
+
+// Validate checks that the routing fields of the head are set.
+func (h Head) Validate() error {
+	switch {
+	case h.Source == "":
+		return fmt.Errorf("%w: missing source", ErrInvalidMessage)
+	case h.Destination == "":
+		return fmt.Errorf("%w: missing destination", ErrInvalidMessage)
+	case h.Eventtype == "":
+		return fmt.Errorf("%w: missing event type", ErrInvalidMessage)
+	}
+	return nil
+}
+
+// ValidateAll validates the head and body of every message and returns all
+// failures joined, each prefixed with the index of the offending message.
+func ValidateAll[T Validator](msgs []Message[T]) error {
+	var errs []error
+	for i, m := range msgs {
+		if err := m.Head.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("message %d: %w", i, err))
+			continue
+		}
+		if err := m.Body.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("message %d: %w: %w", i, ErrInvalidMessage, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,54 @@
+package messages
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type orderBody struct {
+	Qty int
+}
+
+func (b orderBody) Validate() error {
+	if b.Qty <= 0 {
+		return errors.New("quantity must be positive")
+	}
+	return nil
+}
+
+func TestValidateAll(t *testing.T) {
+	head := Head{Source: "api", Destination: "orders", Eventtype: "order.placed"}
+	msgs := []Message[orderBody]{
+		{Head: head, Body: orderBody{Qty: 1}},
+		{Head: Head{Source: "api", Eventtype: "order.placed"}, Body: orderBody{Qty: 1}},
+		{Head: head, Body: orderBody{Qty: 2}},
+		{Head: head, Body: orderBody{Qty: 0}},
+	}
+
+	err := ValidateAll(msgs)
+	if !errors.Is(err, ErrInvalidMessage) {
+		t.Fatalf("ValidateAll = %v, want ErrInvalidMessage", err)
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		t.Fatalf("ValidateAll = %v, want two joined errors", err)
+	}
+	errs := joined.Unwrap()
+	if msg := errs[0].Error(); !strings.HasPrefix(msg, "message 1: ") || !strings.Contains(msg, "missing destination") {
+		t.Errorf("first error = %q", msg)
+	}
+	if msg := errs[1].Error(); !strings.HasPrefix(msg, "message 3: ") || !strings.Contains(msg, "quantity must be positive") {
+		t.Errorf("second error = %q", msg)
+	}
+}
+
+func TestValidateAllValid(t *testing.T) {
+	head := Head{Source: "api", Destination: "orders", Eventtype: "order.placed"}
+	if err := ValidateAll([]Message[orderBody]{{Head: head, Body: orderBody{Qty: 1}}}); err != nil {
+		t.Errorf("ValidateAll = %v", err)
+	}
+	if err := ValidateAll[orderBody](nil); err != nil {
+		t.Errorf("ValidateAll(nil) = %v", err)
+	}
+}